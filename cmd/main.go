@@ -38,19 +38,44 @@ var command = &cobra.Command{
 		}
 		time.Local = loc
 
+		err = dedupeFieldSchedules(db)
+		if err != nil {
+			panic(err)
+		}
+
 		err = db.AutoMigrate(
+			&models.TimeSet{},
 			&models.Field{},
 			&models.FieldSchedule{},
 			&models.Time{},
+			&models.FieldScheduleHistory{},
+			&models.ScheduleRule{},
+			&models.Blackout{},
+			&models.PricingRule{},
+			&models.Promo{},
+			&models.PromoUsage{},
+			&models.PromoUsageSchedule{},
+			&models.Waitlist{},
+			&models.StandingReservation{},
+			&models.Event{},
 		)
 		if err != nil {
 			panic(err)
 		}
+
+		err = restrictTimeDelete(db)
+		if err != nil {
+			panic(err)
+		}
 		client := clients.NewClientRegistry()
 		repository := repositories.NewRepositoryRegistry(db)
 		service := services.NewServiceRegistry(repository)
 		controller := controllers.NewControllerRegistry(service)
 
+		go runReleaseExpiredHold(service)
+		go runExpireWaitlist(service)
+		go runGenerateScheduleHorizon(service)
+
 		router := gin.Default()
 		imagesDir := "./images"
 		router.Static("/images", imagesDir)
@@ -96,6 +121,7 @@ var command = &cobra.Command{
 }
 
 func Run() {
+	command.AddCommand(normalizeTimeCommand)
 	err := command.Execute()
 	if err != nil {
 		panic(err)
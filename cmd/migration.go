@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"field-service/constants"
+	"field-service/domain/models"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const fieldScheduleUniqueIndex = "idx_field_schedules_field_date_time"
+
+// dedupeFieldSchedules menghapus schedule ganda (field, tanggal dan time yang sama) sebelum unique index dibuat.
+// Schedule yang sudah dipesan atau di-hold diprioritaskan untuk disimpan, sisanya di-soft delete.
+// Hanya berjalan sekali, yaitu selama unique index belum ada.
+func dedupeFieldSchedules(db *gorm.DB) error {
+	migrator := db.Migrator()
+	if !migrator.HasTable(&models.FieldSchedule{}) || migrator.HasIndex(&models.FieldSchedule{}, fieldScheduleUniqueIndex) {
+		return nil
+	}
+
+	var removed []struct {
+		UUID   uuid.UUID
+		Status constants.FieldScheduleStatus
+	}
+	err := db.Raw(`
+		UPDATE field_schedules SET deleted_at = NOW()
+		WHERE id IN (
+			SELECT id FROM (
+				SELECT id, ROW_NUMBER() OVER (
+					PARTITION BY field_id, date, time_id
+					ORDER BY CASE status WHEN ? THEN 0 WHEN ? THEN 1 WHEN ? THEN 2 ELSE 3 END, id
+				) AS row_number
+				FROM field_schedules
+				WHERE deleted_at IS NULL
+			) duplicates
+			WHERE row_number > 1
+		)
+		RETURNING uuid, status`,
+		constants.Booked, constants.Held, constants.Maintenance,
+	).Scan(&removed).Error
+	if err != nil {
+		return err
+	}
+
+	for _, item := range removed {
+		if item.Status != constants.Available {
+			logrus.Warnf("removed duplicate field schedule %s with status %s", item.UUID, item.Status.GetStatusString())
+		}
+	}
+
+	if len(removed) > 0 {
+		logrus.Infof("removed %d duplicate field schedule", len(removed))
+	}
+
+	return nil
+}
+
+const fieldScheduleTimeConstraint = "fk_field_schedules_time"
+
+// restrictTimeDelete mengganti foreign key time pada field_schedules menjadi ON DELETE RESTRICT.
+// AutoMigrate tidak mengubah constraint yang sudah ada, jadi database lama harus diubah manual di sini.
+func restrictTimeDelete(db *gorm.DB) error {
+	var deleteRule string
+	err := db.Raw(`
+		SELECT delete_rule FROM information_schema.referential_constraints
+		WHERE constraint_schema = CURRENT_SCHEMA() AND constraint_name = ?`,
+		fieldScheduleTimeConstraint,
+	).Scan(&deleteRule).Error
+	if err != nil {
+		return err
+	}
+
+	if deleteRule == "" || deleteRule == "RESTRICT" {
+		return nil
+	}
+
+	err = db.Exec(`
+		ALTER TABLE field_schedules
+		DROP CONSTRAINT ` + fieldScheduleTimeConstraint + `,
+		ADD CONSTRAINT ` + fieldScheduleTimeConstraint + ` FOREIGN KEY (time_id) REFERENCES times(id)
+		ON UPDATE CASCADE ON DELETE RESTRICT`,
+	).Error
+	if err != nil {
+		return err
+	}
+
+	logrus.Infof("changed %s to on delete restrict", fieldScheduleTimeConstraint)
+	return nil
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"context"
+	"field-service/config"
+	"field-service/repositories"
+	"field-service/services"
+	"github.com/joho/godotenv"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// normalizeTimeCommand adalah migrasi sekali jalan untuk menyamakan time lama ke format HH:MM:SS.
+var normalizeTimeCommand = &cobra.Command{
+	Use:   "normalize-time",
+	Short: "Normalize stored time slots to HH:MM:SS",
+	RunE: func(c *cobra.Command, args []string) error {
+		_ = godotenv.Load(".env")
+		config.Init()
+		db, err := config.InitDatabase()
+		if err != nil {
+			return err
+		}
+
+		service := services.NewServiceRegistry(repositories.NewRepositoryRegistry(db))
+		total, err := service.GetTime().NormalizeStoredTimes(context.Background())
+		if err != nil {
+			return err
+		}
+
+		logrus.Infof("normalized %d stored time", total)
+		return nil
+	},
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"context"
+	"field-service/services"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+const releaseExpiredHoldInterval = time.Minute
+
+func runReleaseExpiredHold(service services.IServiceRegistry) {
+	ticker := time.NewTicker(releaseExpiredHoldInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		total, err := service.GetFieldSchedule().ReleaseExpiredHold(context.Background())
+		if err != nil {
+			logrus.Errorf("failed to release expired hold: %v", err)
+			continue
+		}
+
+		if total > 0 {
+			logrus.Infof("released %d expired field schedule hold", total)
+		}
+	}
+}
+
+const expireWaitlistInterval = time.Hour
+
+func runExpireWaitlist(service services.IServiceRegistry) {
+	ticker := time.NewTicker(expireWaitlistInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		total, err := service.GetWaitlist().Expire(context.Background())
+		if err != nil {
+			logrus.Errorf("failed to expire waitlist: %v", err)
+			continue
+		}
+
+		if total > 0 {
+			logrus.Infof("expired %d waitlist", total)
+		}
+	}
+}
+
+const generateScheduleHorizonInterval = 24 * time.Hour
+
+func generateScheduleHorizon(service services.IServiceRegistry) {
+	result, err := service.GetFieldSchedule().GenerateScheduleHorizon(context.Background())
+	if err != nil {
+		logrus.Errorf("failed to generate schedule horizon: %v", err)
+		return
+	}
+
+	if !result.Acquired {
+		logrus.Infof("schedule horizon is being generated by another instance")
+		return
+	}
+
+	for _, field := range result.Fields {
+		if field.Error != nil {
+			logrus.Errorf("failed to generate schedule for field %s (%s): %v", field.FieldName, field.FieldID, field.Error)
+			continue
+		}
+
+		logrus.Infof("generated schedule for field %s (%s): created %d, skipped %d",
+			field.FieldName,
+			field.FieldID,
+			field.Created,
+			field.Skipped,
+		)
+	}
+}
+
+func runGenerateScheduleHorizon(service services.IServiceRegistry) {
+	generateScheduleHorizon(service)
+
+	ticker := time.NewTicker(generateScheduleHorizonInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		generateScheduleHorizon(service)
+	}
+}
@@ -0,0 +1,251 @@
+package pricing
+
+import (
+	"field-service/common/util"
+	errTime "field-service/constants/error/time"
+	"field-service/domain/models"
+	"math"
+	"sort"
+	"time"
+)
+
+// SchedulePrice adalah hasil perhitungan harga untuk satu schedule.
+type SchedulePrice struct {
+	PricePerHour   int
+	DurationMinute int
+	Price          float64
+	PricingRule    *models.PricingRule
+}
+
+func (s *SchedulePrice) PricingRuleName() string {
+	if s.PricingRule == nil {
+		return ""
+	}
+
+	return s.PricingRule.Name
+}
+
+// IsWeekday mengecek apakah hari dari tanggal termasuk dalam daftar weekday (0 = minggu).
+func IsWeekday(weekdays []int32, date time.Time) bool {
+	for _, weekday := range weekdays {
+		if time.Weekday(weekday) == date.Weekday() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchPricingRule mengecek hari dan tanggal schedule terhadap pricing rule.
+func matchPricingRule(pricingRule *models.PricingRule, fieldID uint, date time.Time) bool {
+	if pricingRule.FieldID != fieldID || !IsWeekday(pricingRule.Weekdays, date) {
+		return false
+	}
+
+	currentDate := date.Format(time.DateOnly)
+	if pricingRule.StartDate != nil && currentDate < pricingRule.StartDate.Format(time.DateOnly) {
+		return false
+	}
+
+	if pricingRule.EndDate != nil && currentDate > pricingRule.EndDate.Format(time.DateOnly) {
+		return false
+	}
+
+	return true
+}
+
+// CoveredRatio menghitung porsi slot yang tertutup rentang jam windowStartTime sampai windowEndTime,
+// sama seperti pricing rule yang hanya berlaku untuk bagian slot yang ditutupinya.
+// Batas yang kosong berarti rentang tidak dibatasi di sisi tersebut.
+func CoveredRatio(scheduleTime *models.Time, windowStartTime, windowEndTime string) (float64, error) {
+	startTime, err := util.ParseTime(scheduleTime.StartTime)
+	if err != nil {
+		return 0, err
+	}
+
+	endTime, err := util.ParseTime(scheduleTime.EndTime)
+	if err != nil {
+		return 0, err
+	}
+
+	coveredStart, coveredEnd := startTime, endTime
+	if windowStartTime != "" {
+		windowStart, err := util.ParseTime(windowStartTime)
+		if err != nil {
+			return 0, err
+		}
+
+		if windowStart.After(coveredStart) {
+			coveredStart = windowStart
+		}
+	}
+
+	if windowEndTime != "" {
+		windowEnd, err := util.ParseTime(windowEndTime)
+		if err != nil {
+			return 0, err
+		}
+
+		if windowEnd.Before(coveredEnd) {
+			coveredEnd = windowEnd
+		}
+	}
+
+	duration := endTime.Sub(startTime)
+	covered := coveredEnd.Sub(coveredStart)
+	if duration <= 0 || covered <= 0 {
+		return 0, nil
+	}
+
+	return covered.Minutes() / duration.Minutes(), nil
+}
+
+type ruleWindow struct {
+	pricingRule *models.PricingRule
+	startTime   time.Time
+	endTime     time.Time
+}
+
+// Resolve menghitung harga schedule dari pricing rule yang cocok. Slot dipecah mengikuti batas jam
+// setiap rule, tiap potongan memakai rule dengan prioritas tertinggi yang menutupinya dan potongan
+// tanpa rule memakai harga field. Buffer field di akhir slot tidak ikut dihargai.
+// pricingRules harus sudah terurut dari prioritas tertinggi.
+func Resolve(
+	field *models.Field,
+	date time.Time,
+	scheduleTime *models.Time,
+	pricingRules []models.PricingRule,
+) (*SchedulePrice, error) {
+	startTime, err := util.ParseTime(scheduleTime.StartTime)
+	if err != nil {
+		return nil, err
+	}
+
+	endTime, err := util.BookableEndTime(scheduleTime.EndTime, field.BufferMinute)
+	if err != nil {
+		return nil, err
+	}
+
+	if !endTime.After(startTime) {
+		return nil, errTime.ErrTimeShorterThanBuffer
+	}
+
+	windows := make([]ruleWindow, 0, len(pricingRules))
+	boundaries := []time.Time{startTime, endTime}
+	for i := range pricingRules {
+		if !matchPricingRule(&pricingRules[i], field.ID, date) {
+			continue
+		}
+
+		ruleStartTime, err := util.ParseTime(pricingRules[i].StartTime)
+		if err != nil {
+			return nil, err
+		}
+
+		ruleEndTime, err := util.ParseTime(pricingRules[i].EndTime)
+		if err != nil {
+			return nil, err
+		}
+
+		windows = append(windows, ruleWindow{
+			pricingRule: &pricingRules[i],
+			startTime:   ruleStartTime,
+			endTime:     ruleEndTime,
+		})
+		for _, boundary := range []time.Time{ruleStartTime, ruleEndTime} {
+			if boundary.After(startTime) && boundary.Before(endTime) {
+				boundaries = append(boundaries, boundary)
+			}
+		}
+	}
+
+	sort.Slice(boundaries, func(i, j int) bool {
+		return boundaries[i].Before(boundaries[j])
+	})
+
+	durationMinute := int(endTime.Sub(startTime).Minutes())
+	result := &SchedulePrice{
+		PricePerHour:   field.PricePerHour,
+		DurationMinute: durationMinute,
+	}
+
+	// rule yang dilaporkan adalah rule yang menutupi porsi terpanjang dari slot
+	var (
+		price         float64
+		longestMinute float64
+	)
+	for i := 0; i < len(boundaries)-1; i++ {
+		segmentMinute := boundaries[i+1].Sub(boundaries[i]).Minutes()
+		if segmentMinute <= 0 {
+			continue
+		}
+
+		pricePerHour := field.PricePerHour
+		var pricingRule *models.PricingRule
+		for j := range windows {
+			if !boundaries[i].Before(windows[j].startTime) && boundaries[i].Before(windows[j].endTime) {
+				pricePerHour = windows[j].pricingRule.PricePerHour
+				pricingRule = windows[j].pricingRule
+				break
+			}
+		}
+
+		price += float64(pricePerHour) * segmentMinute / 60
+		if segmentMinute > longestMinute {
+			longestMinute = segmentMinute
+			result.PricePerHour = pricePerHour
+			result.PricingRule = pricingRule
+		}
+	}
+
+	result.Price = math.Round(price)
+	if durationMinute > 0 && longestMinute < float64(durationMinute) {
+		// slot yang terkena beberapa tarif menampilkan tarif rata-rata per jam
+		result.PricePerHour = int(math.Round(price * 60 / float64(durationMinute)))
+	}
+
+	return result, nil
+}
+
+// OfSchedule memakai harga snapshot yang disimpan saat schedule dibuat atau dipesan beserta nama rule-nya,
+// schedule lama yang belum punya snapshot memakai harga yang berlaku saat ini.
+func OfSchedule(fieldSchedule *models.FieldSchedule, pricingRules []models.PricingRule) (*SchedulePrice, error) {
+	price, err := Resolve(&fieldSchedule.Field, fieldSchedule.Date, &fieldSchedule.Time, pricingRules)
+	if err != nil {
+		return nil, err
+	}
+
+	if fieldSchedule.PricePerHour != nil && fieldSchedule.Price != nil {
+		price.PricePerHour = *fieldSchedule.PricePerHour
+		price.Price = *fieldSchedule.Price
+		price.PricingRule = nil
+		// rule asli bisa sudah diubah atau dihapus, jadi yang dilaporkan hanya nama saat snapshot
+		if fieldSchedule.PricingRuleName != nil {
+			price.PricingRule = &models.PricingRule{Name: *fieldSchedule.PricingRuleName}
+		}
+	}
+
+	return price, nil
+}
+
+// Snapshot mengisi harga snapshot dan nama pricing rule yang dipakai dari harga yang berlaku saat ini.
+func Snapshot(
+	fieldSchedule *models.FieldSchedule,
+	field *models.Field,
+	scheduleTime *models.Time,
+	pricingRules []models.PricingRule,
+) error {
+	price, err := Resolve(field, fieldSchedule.Date, scheduleTime, pricingRules)
+	if err != nil {
+		return err
+	}
+
+	fieldSchedule.PricePerHour = &price.PricePerHour
+	fieldSchedule.Price = &price.Price
+	fieldSchedule.PricingRuleName = nil
+	if price.PricingRule != nil {
+		fieldSchedule.PricingRuleName = &price.PricingRule.Name
+	}
+
+	return nil
+}
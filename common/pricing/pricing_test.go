@@ -0,0 +1,180 @@
+package pricing
+
+import (
+	"field-service/domain/models"
+	"testing"
+	"time"
+)
+
+func TestResolve(t *testing.T) {
+	date := time.Date(2026, time.October, 19, 0, 0, 0, 0, time.Local)
+	otherWeekday := int32((date.Weekday() + 1) % 7)
+	yesterday := date.AddDate(0, 0, -1)
+	field := &models.Field{ID: 1, PricePerHour: 100000}
+	scheduleTime := &models.Time{StartTime: "08:00:00", EndTime: "10:00:00"}
+	newRule := func(name, startTime, endTime string, pricePerHour int) models.PricingRule {
+		return models.PricingRule{
+			FieldID:      field.ID,
+			Name:         name,
+			Weekdays:     []int32{int32(date.Weekday())},
+			StartTime:    startTime,
+			EndTime:      endTime,
+			PricePerHour: pricePerHour,
+		}
+	}
+
+	otherField := newRule("other field", "08:00:00", "10:00:00", 300000)
+	otherField.FieldID = 2
+	otherDay := newRule("other day", "08:00:00", "10:00:00", 300000)
+	otherDay.Weekdays = []int32{otherWeekday}
+	ended := newRule("ended", "08:00:00", "10:00:00", 300000)
+	ended.EndDate = &yesterday
+
+	tests := []struct {
+		name         string
+		pricingRules []models.PricingRule
+		bufferMinute int
+		pricePerHour int
+		price        float64
+		pricingRule  string
+	}{
+		{
+			name:         "field price without rule",
+			pricePerHour: 100000,
+			price:        200000,
+		},
+		{
+			name:         "buffer is not charged",
+			pricingRules: []models.PricingRule{newRule("late", "09:30:00", "10:00:00", 300000)},
+			bufferMinute: 30,
+			pricePerHour: 100000,
+			price:        150000,
+		},
+		{
+			name:         "rule covers whole slot",
+			pricingRules: []models.PricingRule{newRule("peak", "08:00:00", "10:00:00", 150000)},
+			pricePerHour: 150000,
+			price:        300000,
+			pricingRule:  "peak",
+		},
+		{
+			name:         "rule covers part of slot",
+			pricingRules: []models.PricingRule{newRule("evening", "09:00:00", "12:00:00", 160000)},
+			pricePerHour: 130000,
+			price:        260000,
+		},
+		{
+			name:         "rule covers most of slot",
+			pricingRules: []models.PricingRule{newRule("morning", "08:30:00", "12:00:00", 180000)},
+			pricePerHour: 160000,
+			price:        320000,
+			pricingRule:  "morning",
+		},
+		{
+			name: "first rule has highest priority",
+			pricingRules: []models.PricingRule{
+				newRule("high", "08:00:00", "10:00:00", 200000),
+				newRule("low", "08:00:00", "10:00:00", 120000),
+			},
+			pricePerHour: 200000,
+			price:        400000,
+			pricingRule:  "high",
+		},
+		{
+			name:         "rule not matching field, weekday or date is ignored",
+			pricingRules: []models.PricingRule{otherField, otherDay, ended},
+			pricePerHour: 100000,
+			price:        200000,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			field := *field
+			field.BufferMinute = test.bufferMinute
+			result, err := Resolve(&field, date, scheduleTime, test.pricingRules)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if result.DurationMinute != 120-test.bufferMinute {
+				t.Errorf("expected duration %d, got %d", 120-test.bufferMinute, result.DurationMinute)
+			}
+
+			if result.PricePerHour != test.pricePerHour {
+				t.Errorf("expected price per hour %d, got %d", test.pricePerHour, result.PricePerHour)
+			}
+
+			if result.Price != test.price {
+				t.Errorf("expected price %.0f, got %.0f", test.price, result.Price)
+			}
+
+			if result.PricingRuleName() != test.pricingRule {
+				t.Errorf("expected pricing rule %q, got %q", test.pricingRule, result.PricingRuleName())
+			}
+		})
+	}
+}
+
+func TestOfSchedule(t *testing.T) {
+	date := time.Date(2026, time.October, 19, 0, 0, 0, 0, time.Local)
+	pricePerHour := 150000
+	price := float64(150000)
+	ruleName := "peak"
+	fieldSchedule := &models.FieldSchedule{
+		Date:  date,
+		Field: models.Field{ID: 1, PricePerHour: 100000},
+		Time:  models.Time{StartTime: "08:00:00", EndTime: "09:00:00"},
+	}
+
+	result, err := OfSchedule(fieldSchedule, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Price != 100000 || result.PricingRuleName() != "" {
+		t.Fatalf("expected current price 100000 without rule, got %.0f %q", result.Price, result.PricingRuleName())
+	}
+
+	fieldSchedule.PricePerHour = &pricePerHour
+	fieldSchedule.Price = &price
+	fieldSchedule.PricingRuleName = &ruleName
+	result, err = OfSchedule(fieldSchedule, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Price != price || result.PricingRuleName() != ruleName {
+		t.Fatalf("expected snapshot price %.0f with rule %q, got %.0f %q", price, ruleName, result.Price, result.PricingRuleName())
+	}
+}
+
+func TestCoveredRatio(t *testing.T) {
+	scheduleTime := &models.Time{StartTime: "17:00:00", EndTime: "19:00:00"}
+	tests := []struct {
+		name        string
+		windowStart string
+		windowEnd   string
+		ratio       float64
+	}{
+		{name: "without window", ratio: 1},
+		{name: "window covers whole slot", windowStart: "16:00:00", windowEnd: "20:00:00", ratio: 1},
+		{name: "window starts inside slot", windowStart: "18:00:00", ratio: 0.5},
+		{name: "window ends inside slot", windowEnd: "17:30:00", ratio: 0.25},
+		{name: "window before slot", windowStart: "08:00:00", windowEnd: "17:00:00", ratio: 0},
+		{name: "window after slot", windowStart: "19:00:00", ratio: 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ratio, err := CoveredRatio(scheduleTime, test.windowStart, test.windowEnd)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if ratio != test.ratio {
+				t.Fatalf("expected ratio %v, got %v", test.ratio, ratio)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package util
+
+import (
+	"field-service/constants"
+	errFieldSchedule "field-service/constants/error/fieldSchedule"
+	"field-service/domain/models"
+	"fmt"
+	"time"
+)
+
+// ParseDate membaca tanggal dengan format YYYY-MM-DD dalam zona waktu lokal.
+func ParseDate(date string) (time.Time, error) {
+	dateParsed, err := time.ParseInLocation(time.DateOnly, date, time.Local)
+	if err != nil {
+		return time.Time{}, errFieldSchedule.ErrInvalidDate
+	}
+
+	return dateParsed, nil
+}
+
+// ScheduleKey membentuk key unik schedule dari tanggal dan time slot.
+func ScheduleKey(date time.Time, timeID uint) string {
+	return fmt.Sprintf("%s|%d", date.Format(time.DateOnly), timeID)
+}
+
+// TimesForDate mengembalikan time slot yang berlaku pada tanggal tertentu.
+// Tanggal yang tidak masuk rentang tanggal schedule rule mana pun memakai semua time slot,
+// sedangkan tanggal di dalam rentang rule hanya memakai time slot dari rule yang cocok dengan harinya.
+func TimesForDate(date time.Time, times []models.Time, scheduleRules []models.ScheduleRule) []models.Time {
+	// bandingkan dalam format tanggal karena kolom date dibaca tanpa zona waktu lokal
+	currentDate := date.Format(time.DateOnly)
+	covered := false
+	timeIDs := make(map[uint]bool)
+	for _, scheduleRule := range scheduleRules {
+		if currentDate < scheduleRule.StartDate.Format(time.DateOnly) ||
+			currentDate > scheduleRule.EndDate.Format(time.DateOnly) {
+			continue
+		}
+		covered = true
+
+		matchWeekday := false
+		for _, weekday := range scheduleRule.Weekdays {
+			if time.Weekday(weekday) == date.Weekday() {
+				matchWeekday = true
+				break
+			}
+		}
+		if !matchWeekday {
+			continue
+		}
+
+		for _, item := range scheduleRule.Times {
+			timeIDs[item.ID] = true
+		}
+	}
+
+	if !covered {
+		return times
+	}
+
+	result := make([]models.Time, 0, len(timeIDs))
+	for _, item := range times {
+		if timeIDs[item.ID] {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
+
+// IsBlackout mengecek apakah tanggal masuk ke salah satu rentang blackout.
+func IsBlackout(date time.Time, blackouts []models.Blackout) bool {
+	currentDate := date.Format(time.DateOnly)
+	for _, blackout := range blackouts {
+		if currentDate >= blackout.StartDate.Format(time.DateOnly) &&
+			currentDate <= blackout.EndDate.Format(time.DateOnly) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// UniqueFieldScheduleIDs membuang id schedule yang dikirim lebih dari sekali dengan urutan tetap.
+func UniqueFieldScheduleIDs(fieldScheduleIDs []string) []string {
+	seen := make(map[string]bool, len(fieldScheduleIDs))
+	result := make([]string, 0, len(fieldScheduleIDs))
+	for _, item := range fieldScheduleIDs {
+		if seen[item] {
+			continue
+		}
+		seen[item] = true
+		result = append(result, item)
+	}
+
+	return result
+}
+
+// IsHoldExpired mengecek apakah hold pada schedule sudah lewat batas waktunya.
+func IsHoldExpired(fieldSchedule models.FieldSchedule, now time.Time) bool {
+	return fieldSchedule.Status == constants.Held &&
+		fieldSchedule.HeldUntil != nil &&
+		!fieldSchedule.HeldUntil.After(now)
+}
+
+// FieldScheduleIDs mengambil id dari daftar schedule.
+func FieldScheduleIDs(fieldSchedules []models.FieldSchedule) []uint {
+	ids := make([]uint, 0, len(fieldSchedules))
+	for _, fieldSchedule := range fieldSchedules {
+		ids = append(ids, fieldSchedule.ID)
+	}
+
+	return ids
+}
+
+// FieldIDs mengambil id field dari daftar schedule.
+func FieldIDs(fieldSchedules []models.FieldSchedule) []uint {
+	ids := make([]uint, 0, len(fieldSchedules))
+	for _, fieldSchedule := range fieldSchedules {
+		ids = append(ids, fieldSchedule.FieldID)
+	}
+
+	return ids
+}
+
+// ScheduleStartAt menggabungkan tanggal dan jam mulai schedule dalam zona waktu lokal.
+func ScheduleStartAt(fieldSchedule *models.FieldSchedule) (time.Time, error) {
+	return dateTimeAt(fieldSchedule.Date, fieldSchedule.Time.StartTime)
+}
+
+// ScheduleEndAt menggabungkan tanggal dan jam selesai schedule dalam zona waktu lokal.
+func ScheduleEndAt(fieldSchedule *models.FieldSchedule) (time.Time, error) {
+	return dateTimeAt(fieldSchedule.Date, fieldSchedule.Time.EndTime)
+}
+
+func dateTimeAt(date time.Time, value string) (time.Time, error) {
+	clock, err := ParseTime(value)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Date(
+		date.Year(), date.Month(), date.Day(),
+		clock.Hour(), clock.Minute(), clock.Second(), 0,
+		time.Local,
+	), nil
+}
@@ -0,0 +1,77 @@
+package util
+
+import (
+	"field-service/domain/models"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestTimesForDate(t *testing.T) {
+	times := []models.Time{{ID: 1}, {ID: 2}, {ID: 3}}
+	startDate := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.Local)
+	endDate := time.Date(2026, time.October, 31, 0, 0, 0, 0, time.Local)
+	weekend := models.ScheduleRule{
+		Weekdays:  []int32{int32(time.Saturday), int32(time.Sunday)},
+		StartDate: startDate,
+		EndDate:   endDate,
+		Times:     []models.Time{{ID: 1}},
+	}
+	sunday := models.ScheduleRule{
+		Weekdays:  []int32{int32(time.Sunday)},
+		StartDate: startDate,
+		EndDate:   endDate,
+		Times:     []models.Time{{ID: 3}},
+	}
+
+	tests := []struct {
+		name          string
+		date          time.Time
+		scheduleRules []models.ScheduleRule
+		timeIDs       []uint
+	}{
+		{
+			name:    "without rule",
+			date:    time.Date(2026, time.October, 18, 0, 0, 0, 0, time.Local),
+			timeIDs: []uint{1, 2, 3},
+		},
+		{
+			name:          "outside rule date range",
+			date:          time.Date(2026, time.November, 1, 0, 0, 0, 0, time.Local),
+			scheduleRules: []models.ScheduleRule{weekend},
+			timeIDs:       []uint{1, 2, 3},
+		},
+		{
+			name:          "matching weekday",
+			date:          time.Date(2026, time.October, 17, 0, 0, 0, 0, time.Local),
+			scheduleRules: []models.ScheduleRule{weekend, sunday},
+			timeIDs:       []uint{1},
+		},
+		{
+			name:          "times from every matching rule",
+			date:          time.Date(2026, time.October, 18, 0, 0, 0, 0, time.Local),
+			scheduleRules: []models.ScheduleRule{weekend, sunday},
+			timeIDs:       []uint{1, 3},
+		},
+		{
+			name:          "weekday not in rule is closed",
+			date:          time.Date(2026, time.October, 19, 0, 0, 0, 0, time.Local),
+			scheduleRules: []models.ScheduleRule{weekend},
+			timeIDs:       []uint{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := TimesForDate(test.date, times, test.scheduleRules)
+			timeIDs := make([]uint, 0, len(result))
+			for _, item := range result {
+				timeIDs = append(timeIDs, item.ID)
+			}
+
+			if !slices.Equal(timeIDs, test.timeIDs) {
+				t.Fatalf("expected time ids %v, got %v", test.timeIDs, timeIDs)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package util
+
+import (
+	errTime "field-service/constants/error/time"
+	"time"
+)
+
+const TimeFormat = "15:04:05"
+
+// ParseTime menerima jam dengan format HH:MM atau HH:MM:SS.
+func ParseTime(value string) (time.Time, error) {
+	for _, layout := range []string{TimeFormat, "15:04"} {
+		parsed, err := time.Parse(layout, value)
+		if err == nil {
+			return parsed, nil
+		}
+	}
+
+	return time.Time{}, errTime.ErrInvalidTimeFormat
+}
+
+// NormalizeTime mengubah jam ke format HH:MM:SS yang disimpan di database.
+func NormalizeTime(value string) (string, error) {
+	parsed, err := ParseTime(value)
+	if err != nil {
+		return "", err
+	}
+
+	return parsed.Format(TimeFormat), nil
+}
+
+// DurationMinute menghitung durasi slot dalam menit dari jam mulai dan jam selesai.
+func DurationMinute(startTime, endTime string) (int, error) {
+	start, err := ParseTime(startTime)
+	if err != nil {
+		return 0, err
+	}
+
+	end, err := ParseTime(endTime)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(end.Sub(start).Minutes()), nil
+}
+
+// CheckBuffer memastikan slot masih punya waktu yang bisa dipesan setelah dikurangi buffer field.
+func CheckBuffer(startTime, endTime string, bufferMinute int) error {
+	durationMinute, err := DurationMinute(startTime, endTime)
+	if err != nil {
+		return err
+	}
+
+	if durationMinute <= bufferMinute {
+		return errTime.ErrTimeShorterThanBuffer
+	}
+
+	return nil
+}
+
+// BookableEndTime mengembalikan jam selesai yang bisa dipesan, yaitu jam selesai slot dikurangi buffer field.
+func BookableEndTime(endTime string, bufferMinute int) (time.Time, error) {
+	end, err := ParseTime(endTime)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return end.Add(-time.Duration(bufferMinute) * time.Minute), nil
+}
+
+// IsSameTimeSet membandingkan dua time set, nil berarti time set default.
+func IsSameTimeSet(first, second *uint) bool {
+	if first == nil || second == nil {
+		return first == nil && second == nil
+	}
+
+	return *first == *second
+}
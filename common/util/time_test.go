@@ -0,0 +1,33 @@
+package util
+
+import (
+	"errors"
+	errTime "field-service/constants/error/time"
+	"testing"
+)
+
+func TestCheckBuffer(t *testing.T) {
+	tests := []struct {
+		name         string
+		startTime    string
+		endTime      string
+		bufferMinute int
+		err          error
+	}{
+		{name: "without buffer", startTime: "08:00:00", endTime: "09:00:00", bufferMinute: 0},
+		{name: "buffer shorter than slot", startTime: "08:00:00", endTime: "09:00:00", bufferMinute: 15},
+		{name: "short time format", startTime: "08:00", endTime: "09:30", bufferMinute: 89},
+		{name: "buffer equal to slot", startTime: "08:00:00", endTime: "09:00:00", bufferMinute: 60, err: errTime.ErrTimeShorterThanBuffer},
+		{name: "buffer longer than slot", startTime: "08:00:00", endTime: "08:30:00", bufferMinute: 45, err: errTime.ErrTimeShorterThanBuffer},
+		{name: "invalid time", startTime: "8 pagi", endTime: "09:00:00", bufferMinute: 15, err: errTime.ErrInvalidTimeFormat},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := CheckBuffer(test.startTime, test.endTime, test.bufferMinute)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("expected error %v, got %v", test.err, err)
+			}
+		})
+	}
+}
@@ -10,14 +10,17 @@ import (
 var Config AppConfig
 
 type AppConfig struct {
-	Port                  int             `json:"port"`
-	AppName               string          `json:"appName"`
-	AppEnv                string          `json:"appEnv"`
-	SignatureKey          string          `json:"signatureKey"`
-	Database              Database        `json:"database"`
-	RateLimiterMaxRequest float64         `json:"rateLimiterMaxRequest"`
-	RateLimiterTimeSecond int             `json:"rateLimiterTimeSecond"`
-	InternalService       InternalService `json:"internalService"`
+	Port                        int             `json:"port"`
+	AppName                     string          `json:"appName"`
+	AppEnv                      string          `json:"appEnv"`
+	SignatureKey                string          `json:"signatureKey"`
+	Database                    Database        `json:"database"`
+	RateLimiterMaxRequest       float64         `json:"rateLimiterMaxRequest"`
+	RateLimiterTimeSecond       int             `json:"rateLimiterTimeSecond"`
+	FieldScheduleHoldTimeSecond int             `json:"fieldScheduleHoldTimeSecond"`
+	ScheduleHorizonDay          int             `json:"scheduleHorizonDay"`
+	QuoteExpirySecond           int             `json:"quoteExpirySecond"`
+	InternalService             InternalService `json:"internalService"`
 }
 
 type Database struct {
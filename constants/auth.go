@@ -2,4 +2,5 @@ package constants
 
 const (
 	Token = "token"
+	User  = "user"
 )
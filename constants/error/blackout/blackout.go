@@ -0,0 +1,13 @@
+package error
+
+import "errors"
+
+var (
+	ErrBlackoutNotFound = errors.New("blackout not found")
+	ErrDateIsBlackout   = errors.New("field is closed on this date")
+)
+
+var BlackoutErrors = []error{
+	ErrBlackoutNotFound,
+	ErrDateIsBlackout,
+}
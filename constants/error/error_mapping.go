@@ -1,14 +1,30 @@
 package error
 
 import (
+	errBlackout "field-service/constants/error/blackout"
+	errEvent "field-service/constants/error/event"
 	errorField "field-service/constants/error/field"
 	errFieldSchedule "field-service/constants/error/fieldSchedule"
+	errPricingRule "field-service/constants/error/pricingRule"
+	errPromo "field-service/constants/error/promo"
+	errScheduleRule "field-service/constants/error/scheduleRule"
+	errStandingReservation "field-service/constants/error/standingReservation"
 	errTime "field-service/constants/error/time"
+	errTimeSet "field-service/constants/error/timeSet"
+	errWaitlist "field-service/constants/error/waitlist"
 )
 
 func ErrMapping(err error) bool {
 	allErrors := make([]error, 0)
 	allErrors = append(append(append(GeneralErrors[:], errorField.FieldErrors[:]...), errFieldSchedule.FieldScheduleErrors[:]...), errTime.TimeErrors[:]...)
+	allErrors = append(allErrors, errScheduleRule.ScheduleRuleErrors[:]...)
+	allErrors = append(allErrors, errBlackout.BlackoutErrors[:]...)
+	allErrors = append(allErrors, errTimeSet.TimeSetErrors[:]...)
+	allErrors = append(allErrors, errPricingRule.PricingRuleErrors[:]...)
+	allErrors = append(allErrors, errPromo.PromoErrors[:]...)
+	allErrors = append(allErrors, errWaitlist.WaitlistErrors[:]...)
+	allErrors = append(allErrors, errStandingReservation.StandingReservationErrors[:]...)
+	allErrors = append(allErrors, errEvent.EventErrors[:]...)
 
 	for _, item := range allErrors {
 		if err.Error() == item.Error() {
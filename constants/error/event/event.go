@@ -0,0 +1,15 @@
+package error
+
+import "errors"
+
+var (
+	ErrEventNotFound  = errors.New("event not found")
+	ErrEventConflict  = errors.New("some field schedules in the event are not available")
+	ErrEventCancelled = errors.New("event is already cancelled")
+)
+
+var EventErrors = []error{
+	ErrEventNotFound,
+	ErrEventConflict,
+	ErrEventCancelled,
+}
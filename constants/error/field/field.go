@@ -3,9 +3,17 @@ package error
 import "errors"
 
 var (
-	ErrFieldNotFound = errors.New("field not found")
+	ErrFieldNotFound          = errors.New("field not found")
+	ErrInvalidParentField     = errors.New("parent field must be another field without a parent")
+	ErrFieldHasChildren       = errors.New("field with child fields cannot have a parent")
+	ErrFieldHasBookedSchedule = errors.New("time set cannot change while upcoming schedules are booked, held or in maintenance")
+	ErrLinkedFieldIsBooked    = errors.New("fields cannot be linked while both have overlapping upcoming bookings")
 )
 
 var FieldErrors = []error{
 	ErrFieldNotFound,
+	ErrInvalidParentField,
+	ErrFieldHasChildren,
+	ErrFieldHasBookedSchedule,
+	ErrLinkedFieldIsBooked,
 }
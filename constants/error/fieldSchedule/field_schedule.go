@@ -3,11 +3,41 @@ package error
 import "errors"
 
 var (
-	ErrFieldScheduleNotFound = errors.New("field schedule not found")
-	ErrFieldScheduleIsExist  = errors.New("field schedule already exist")
+	ErrFieldScheduleNotFound           = errors.New("field schedule not found")
+	ErrFieldScheduleIsExist            = errors.New("field schedule already exist")
+	ErrFieldScheduleNotAvailable       = errors.New("field schedule is not available")
+	ErrInvalidDate                     = errors.New("invalid date, use format YYYY-MM-DD")
+	ErrInvalidDateRange                = errors.New("end date must not be before start date")
+	ErrDateRangeTooLong                = errors.New("date range is too long")
+	ErrFieldScheduleNotSameField       = errors.New("all field schedules must belong to the same field")
+	ErrFieldScheduleInPast             = errors.New("field schedule is already in the past")
+	ErrInvalidQuoteToken               = errors.New("invalid quote token")
+	ErrQuoteTokenExpired               = errors.New("quote token has expired")
+	ErrQuotePriceChanged               = errors.New("price has changed since the quote was issued")
+	ErrFieldScheduleNotSameDate        = errors.New("all field schedules must be on the same date")
+	ErrInvalidMonth                    = errors.New("invalid month, use format YYYY-MM")
+	ErrFieldScheduleNotConsecutive     = errors.New("field schedules must be consecutive without gaps")
+	ErrLinkedFieldScheduleNotAvailable = errors.New("linked field schedule is not available")
+	ErrInvalidUserID                   = errors.New("invalid user id")
+	ErrFieldScheduleInUse              = errors.New("field schedule is booked or held")
 )
 
 var FieldScheduleErrors = []error{
 	ErrFieldScheduleNotFound,
 	ErrFieldScheduleIsExist,
+	ErrFieldScheduleNotAvailable,
+	ErrInvalidDate,
+	ErrInvalidDateRange,
+	ErrDateRangeTooLong,
+	ErrFieldScheduleNotSameField,
+	ErrFieldScheduleInPast,
+	ErrInvalidQuoteToken,
+	ErrQuoteTokenExpired,
+	ErrQuotePriceChanged,
+	ErrFieldScheduleNotSameDate,
+	ErrFieldScheduleNotConsecutive,
+	ErrInvalidMonth,
+	ErrLinkedFieldScheduleNotAvailable,
+	ErrInvalidUserID,
+	ErrFieldScheduleInUse,
 }
@@ -0,0 +1,11 @@
+package error
+
+import "errors"
+
+var (
+	ErrPricingRuleNotFound = errors.New("pricing rule not found")
+)
+
+var PricingRuleErrors = []error{
+	ErrPricingRuleNotFound,
+}
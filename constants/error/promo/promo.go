@@ -0,0 +1,29 @@
+package error
+
+import "errors"
+
+var (
+	ErrPromoNotFound          = errors.New("promo not found")
+	ErrPromoCodeIsExist       = errors.New("promo code already exist")
+	ErrInvalidDiscountValue   = errors.New("percentage discount must not be more than 100")
+	ErrPromoNotActive         = errors.New("promo is not active")
+	ErrPromoUsageLimitReached = errors.New("promo usage limit has been reached")
+	ErrPromoUserLimitReached  = errors.New("promo usage limit for this user has been reached")
+	ErrPromoNotApplicable     = errors.New("promo is not applicable to the selected schedules")
+	ErrPromoAlreadyRedeemed   = errors.New("promo has already been redeemed for this reference")
+	ErrPromoFirstBookingOnly  = errors.New("promo is only for the first booking")
+	ErrPromoScheduleNotOwned  = errors.New("promo can only be redeemed for schedules booked or held by the user")
+)
+
+var PromoErrors = []error{
+	ErrPromoNotFound,
+	ErrPromoCodeIsExist,
+	ErrInvalidDiscountValue,
+	ErrPromoNotActive,
+	ErrPromoUsageLimitReached,
+	ErrPromoUserLimitReached,
+	ErrPromoNotApplicable,
+	ErrPromoAlreadyRedeemed,
+	ErrPromoFirstBookingOnly,
+	ErrPromoScheduleNotOwned,
+}
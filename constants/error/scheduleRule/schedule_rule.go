@@ -0,0 +1,11 @@
+package error
+
+import "errors"
+
+var (
+	ErrScheduleRuleNotFound = errors.New("schedule rule not found")
+)
+
+var ScheduleRuleErrors = []error{
+	ErrScheduleRuleNotFound,
+}
@@ -0,0 +1,19 @@
+package error
+
+import "errors"
+
+var (
+	ErrStandingReservationNotFound  = errors.New("standing reservation not found")
+	ErrStandingReservationOverlap   = errors.New("standing reservation overlaps with an existing standing reservation")
+	ErrStandingReservationConflict  = errors.New("some dates in the standing reservation are not available")
+	ErrStandingReservationCancelled = errors.New("standing reservation is already cancelled")
+	ErrStandingReservationNoDate    = errors.New("date range does not contain the selected weekday")
+)
+
+var StandingReservationErrors = []error{
+	ErrStandingReservationNotFound,
+	ErrStandingReservationOverlap,
+	ErrStandingReservationConflict,
+	ErrStandingReservationCancelled,
+	ErrStandingReservationNoDate,
+}
@@ -3,9 +3,21 @@ package error
 import "errors"
 
 var (
-	ErrTimeNotFound = errors.New("time not found")
+	ErrTimeNotFound            = errors.New("time not found")
+	ErrInvalidTimeFormat       = errors.New("invalid time, use format HH:MM or HH:MM:SS")
+	ErrInvalidTimeRange        = errors.New("end time must be after start time, slots crossing midnight are not supported")
+	ErrTimeOverlap             = errors.New("time overlaps with an existing time")
+	ErrTimeHasBookedSchedule   = errors.New("time is used by a booked schedule")
+	ErrTimeHasUpcomingSchedule = errors.New("time is used by upcoming schedules, set moveSchedules to move them")
+	ErrTimeShorterThanBuffer   = errors.New("time slot must be longer than the field buffer")
 )
 
 var TimeErrors = []error{
 	ErrTimeNotFound,
+	ErrInvalidTimeFormat,
+	ErrInvalidTimeRange,
+	ErrTimeOverlap,
+	ErrTimeHasBookedSchedule,
+	ErrTimeHasUpcomingSchedule,
+	ErrTimeShorterThanBuffer,
 }
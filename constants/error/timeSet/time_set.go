@@ -0,0 +1,15 @@
+package error
+
+import "errors"
+
+var (
+	ErrTimeSetNotFound  = errors.New("time set not found")
+	ErrTimeSetInUse     = errors.New("time set is still used by a field or time")
+	ErrTimeNotInTimeSet = errors.New("time does not belong to the field's time set")
+)
+
+var TimeSetErrors = []error{
+	ErrTimeSetNotFound,
+	ErrTimeSetInUse,
+	ErrTimeNotInTimeSet,
+}
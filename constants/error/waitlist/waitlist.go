@@ -0,0 +1,17 @@
+package error
+
+import "errors"
+
+var (
+	ErrWaitlistNotFound   = errors.New("waitlist not found")
+	ErrWaitlistIsExist    = errors.New("already in waitlist for this field schedule")
+	ErrWaitlistNotAllowed = errors.New("only booked or held field schedule can be waitlisted")
+	ErrWaitlistNotActive  = errors.New("waitlist is no longer active")
+)
+
+var WaitlistErrors = []error{
+	ErrWaitlistNotFound,
+	ErrWaitlistIsExist,
+	ErrWaitlistNotAllowed,
+	ErrWaitlistNotActive,
+}
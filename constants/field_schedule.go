@@ -1,24 +1,50 @@
 package constants
 
+const (
+	DefaultFieldScheduleHoldTimeSecond  = 900
+	DefaultGenerateScheduleNumberOfDays = 30
+	MaxGenerateScheduleNumberOfDays     = 366
+	GenerateScheduleStrictMode          = "strict"
+	GenerateScheduleSkipMode            = "skip"
+	DefaultScheduleHorizonDay           = 30
+	ScheduleHorizonLockKey              = 100200300
+	StandingReservationLockKey          = 100200301
+	FieldGroupLockKey                   = 100200302
+	DefaultQuoteExpirySecond            = 300
+	MaxCalendarNumberOfDays             = 62
+)
+
 type FieldScheduleStatusName string
 type FieldScheduleStatus int
 
 const (
-	Available FieldScheduleStatus = 100
-	Booked    FieldScheduleStatus = 200
+	Available   FieldScheduleStatus = 100
+	Booked      FieldScheduleStatus = 200
+	Held        FieldScheduleStatus = 300
+	Maintenance FieldScheduleStatus = 400
+	Unavailable FieldScheduleStatus = 500
 
-	AvailableString FieldScheduleStatusName = "Available"
-	BookedString    FieldScheduleStatusName = "Booked"
+	AvailableString   FieldScheduleStatusName = "Available"
+	BookedString      FieldScheduleStatusName = "Booked"
+	HeldString        FieldScheduleStatusName = "Held"
+	MaintenanceString FieldScheduleStatusName = "Maintenance"
+	UnavailableString FieldScheduleStatusName = "Unavailable"
 )
 
 var mapFieldScheduleStatusIntToString = map[FieldScheduleStatus]FieldScheduleStatusName{
-	Available: AvailableString,
-	Booked:    BookedString,
+	Available:   AvailableString,
+	Booked:      BookedString,
+	Held:        HeldString,
+	Maintenance: MaintenanceString,
+	Unavailable: UnavailableString,
 }
 
 var mapFieldScheduleStatusStringToInt = map[FieldScheduleStatusName]FieldScheduleStatus{
-	AvailableString: Available,
-	BookedString:    Booked,
+	AvailableString:   Available,
+	BookedString:      Booked,
+	HeldString:        Held,
+	MaintenanceString: Maintenance,
+	UnavailableString: Unavailable,
 }
 
 func (f FieldScheduleStatus) GetStatusString() FieldScheduleStatusName {
@@ -0,0 +1,6 @@
+package constants
+
+const (
+	PromoPercentage = "percentage"
+	PromoFixed      = "fixed"
+)
@@ -0,0 +1,30 @@
+package constants
+
+type WaitlistStatusName string
+type WaitlistStatus int
+
+const (
+	WaitlistWaiting   WaitlistStatus = 100
+	WaitlistOffered   WaitlistStatus = 200
+	WaitlistFulfilled WaitlistStatus = 300
+	WaitlistExpired   WaitlistStatus = 400
+	WaitlistCancelled WaitlistStatus = 500
+
+	WaitlistWaitingString   WaitlistStatusName = "Waiting"
+	WaitlistOfferedString   WaitlistStatusName = "Offered"
+	WaitlistFulfilledString WaitlistStatusName = "Fulfilled"
+	WaitlistExpiredString   WaitlistStatusName = "Expired"
+	WaitlistCancelledString WaitlistStatusName = "Cancelled"
+)
+
+var mapWaitlistStatusIntToString = map[WaitlistStatus]WaitlistStatusName{
+	WaitlistWaiting:   WaitlistWaitingString,
+	WaitlistOffered:   WaitlistOfferedString,
+	WaitlistFulfilled: WaitlistFulfilledString,
+	WaitlistExpired:   WaitlistExpiredString,
+	WaitlistCancelled: WaitlistCancelledString,
+}
+
+func (w WaitlistStatus) GetStatusString() WaitlistStatusName {
+	return mapWaitlistStatusIntToString[w]
+}
@@ -3,6 +3,7 @@ package controllers
 import (
 	errValidation "field-service/common/error"
 	"field-service/common/response"
+	"field-service/constants"
 	"field-service/domain/dto"
 	"field-service/services"
 	"github.com/gin-gonic/gin"
@@ -17,10 +18,17 @@ type FieldScheduleController struct {
 type IFieldScheduleController interface {
 	GetAllWithPagination(*gin.Context)
 	GetAllByFieldIDAndDate(*gin.Context)
+	GetCalendarByFieldID(*gin.Context)
+	Search(*gin.Context)
 	GetByUUID(*gin.Context)
 	Create(*gin.Context)
 	Update(*gin.Context)
 	UpdateStatus(*gin.Context)
+	Hold(*gin.Context)
+	Quote(*gin.Context)
+	Release(*gin.Context)
+	StartMaintenance(*gin.Context)
+	FinishMaintenance(*gin.Context)
 	Delete(*gin.Context)
 	GenerateScheduleForOneMonth(*gin.Context)
 }
@@ -119,6 +127,94 @@ func (f *FieldScheduleController) GetAllByFieldIDAndDate(ctx *gin.Context) {
 	})
 }
 
+func (f *FieldScheduleController) GetCalendarByFieldID(ctx *gin.Context) {
+	var params dto.FieldScheduleCalendarRequestParam
+	if err := ctx.ShouldBindQuery(&params); err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err := validate.Struct(params)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errorResponse := errValidation.ErrValidationResponse(err)
+
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:    http.StatusUnprocessableEntity,
+			Err:     err,
+			Message: &errMessage,
+			Data:    errorResponse,
+			Gin:     ctx,
+		})
+		return
+	}
+
+	result, err := f.service.GetFieldSchedule().GetCalendarByFieldID(ctx, ctx.Param("uuid"), &params)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  ctx,
+	})
+}
+
+func (f *FieldScheduleController) Search(ctx *gin.Context) {
+	var params dto.FieldScheduleSearchRequestParam
+	if err := ctx.ShouldBindQuery(&params); err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err := validate.Struct(params)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errorResponse := errValidation.ErrValidationResponse(err)
+
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:    http.StatusUnprocessableEntity,
+			Err:     err,
+			Message: &errMessage,
+			Data:    errorResponse,
+			Gin:     ctx,
+		})
+		return
+	}
+
+	result, err := f.service.GetFieldSchedule().Search(ctx, &params)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  ctx,
+	})
+}
+
 func (f *FieldScheduleController) GetByUUID(ctx *gin.Context) {
 	result, err := f.service.GetFieldSchedule().GetByUUID(ctx, ctx.Param("uuid"))
 	if err != nil {
@@ -251,7 +347,229 @@ func (f *FieldScheduleController) UpdateStatus(ctx *gin.Context) {
 		return
 	}
 
-	err = f.service.GetFieldSchedule().UpdateStatus(ctx, &request)
+	result, err := f.service.GetFieldSchedule().UpdateStatus(ctx, &request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Data: result,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  ctx,
+	})
+}
+
+func (f *FieldScheduleController) Hold(ctx *gin.Context) {
+	var request dto.HoldFieldScheduleRequest
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errorResponse := errValidation.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:    http.StatusUnprocessableEntity,
+			Err:     err,
+			Message: &errMessage,
+			Data:    errorResponse,
+			Gin:     ctx,
+		})
+		return
+	}
+
+	result, err := f.service.GetFieldSchedule().Hold(ctx, &request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Data: result,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  ctx,
+	})
+}
+
+func (f *FieldScheduleController) Quote(ctx *gin.Context) {
+	var request dto.QuoteFieldScheduleRequest
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errorResponse := errValidation.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:    http.StatusUnprocessableEntity,
+			Err:     err,
+			Message: &errMessage,
+			Data:    errorResponse,
+			Gin:     ctx,
+		})
+		return
+	}
+
+	result, err := f.service.GetFieldSchedule().Quote(ctx, &request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Data: result,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  ctx,
+	})
+}
+
+func (f *FieldScheduleController) Release(ctx *gin.Context) {
+	var request dto.ReleaseFieldScheduleRequest
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errorResponse := errValidation.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:    http.StatusUnprocessableEntity,
+			Err:     err,
+			Message: &errMessage,
+			Data:    errorResponse,
+			Gin:     ctx,
+		})
+		return
+	}
+
+	err = f.service.GetFieldSchedule().Release(ctx, &request, ctx.GetHeader(constants.XServiceName))
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Gin:  ctx,
+	})
+}
+
+func (f *FieldScheduleController) StartMaintenance(ctx *gin.Context) {
+	var request dto.MaintenanceFieldScheduleRequest
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errorResponse := errValidation.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:    http.StatusUnprocessableEntity,
+			Err:     err,
+			Message: &errMessage,
+			Data:    errorResponse,
+			Gin:     ctx,
+		})
+		return
+	}
+
+	result, err := f.service.GetFieldSchedule().StartMaintenance(ctx, &request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHTTPResp{
+		Code: http.StatusOK,
+		Data: result,
+		Gin:  ctx,
+	})
+}
+
+func (f *FieldScheduleController) FinishMaintenance(ctx *gin.Context) {
+	var request dto.MaintenanceFieldScheduleRequest
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		response.HttpResponse(response.ParamHTTPResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errorResponse := errValidation.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHTTPResp{
+			Code:    http.StatusUnprocessableEntity,
+			Err:     err,
+			Message: &errMessage,
+			Data:    errorResponse,
+			Gin:     ctx,
+		})
+		return
+	}
+
+	result, err := f.service.GetFieldSchedule().FinishMaintenance(ctx, &request)
 	if err != nil {
 		response.HttpResponse(response.ParamHTTPResp{
 			Code: http.StatusBadRequest,
@@ -263,6 +581,7 @@ func (f *FieldScheduleController) UpdateStatus(ctx *gin.Context) {
 
 	response.HttpResponse(response.ParamHTTPResp{
 		Code: http.StatusOK,
+		Data: result,
 		Gin:  ctx,
 	})
 }
@@ -311,7 +630,7 @@ func (f *FieldScheduleController) GenerateScheduleForOneMonth(ctx *gin.Context)
 		return
 	}
 
-	err = f.service.GetFieldSchedule().GenerateScheduleForOneMonth(ctx, &params)
+	result, err := f.service.GetFieldSchedule().GenerateScheduleForOneMonth(ctx, &params)
 	if err != nil {
 		response.HttpResponse(response.ParamHTTPResp{
 			Code: http.StatusBadRequest,
@@ -323,6 +642,7 @@ func (f *FieldScheduleController) GenerateScheduleForOneMonth(ctx *gin.Context)
 
 	response.HttpResponse(response.ParamHTTPResp{
 		Code: http.StatusCreated,
+		Data: result,
 		Gin:  ctx,
 	})
 }
@@ -1,9 +1,17 @@
 package controllers
 
 import (
+	blackoutController "field-service/controllers/blackout"
+	eventController "field-service/controllers/event"
 	fieldController "field-service/controllers/field"
 	fieldScheduleController "field-service/controllers/field_schedule"
+	pricingRuleController "field-service/controllers/pricing_rule"
+	promoController "field-service/controllers/promo"
+	scheduleRuleController "field-service/controllers/schedule_rule"
+	standingReservationController "field-service/controllers/standing_reservation"
 	timeController "field-service/controllers/time"
+	timeSetController "field-service/controllers/time_set"
+	waitlistController "field-service/controllers/waitlist"
 	"field-service/services"
 )
 
@@ -15,6 +23,14 @@ type IControllerRegistry interface {
 	GetField() fieldController.IFieldController
 	GetFieldSchedule() fieldScheduleController.IFieldScheduleController
 	GetTime() timeController.ITimeController
+	GetScheduleRule() scheduleRuleController.IScheduleRuleController
+	GetBlackout() blackoutController.IBlackoutController
+	GetTimeSet() timeSetController.ITimeSetController
+	GetPricingRule() pricingRuleController.IPricingRuleController
+	GetPromo() promoController.IPromoController
+	GetWaitlist() waitlistController.IWaitlistController
+	GetStandingReservation() standingReservationController.IStandingReservationController
+	GetEvent() eventController.IEventController
 }
 
 func NewControllerRegistry(service services.IServiceRegistry) IControllerRegistry {
@@ -32,3 +48,35 @@ func (r *Registry) GetFieldSchedule() fieldScheduleController.IFieldScheduleCont
 func (r *Registry) GetTime() timeController.ITimeController {
 	return timeController.NewTimeController(r.service)
 }
+
+func (r *Registry) GetScheduleRule() scheduleRuleController.IScheduleRuleController {
+	return scheduleRuleController.NewScheduleRuleController(r.service)
+}
+
+func (r *Registry) GetBlackout() blackoutController.IBlackoutController {
+	return blackoutController.NewBlackoutController(r.service)
+}
+
+func (r *Registry) GetTimeSet() timeSetController.ITimeSetController {
+	return timeSetController.NewTimeSetController(r.service)
+}
+
+func (r *Registry) GetPricingRule() pricingRuleController.IPricingRuleController {
+	return pricingRuleController.NewPricingRuleController(r.service)
+}
+
+func (r *Registry) GetPromo() promoController.IPromoController {
+	return promoController.NewPromoController(r.service)
+}
+
+func (r *Registry) GetWaitlist() waitlistController.IWaitlistController {
+	return waitlistController.NewWaitlistController(r.service)
+}
+
+func (r *Registry) GetStandingReservation() standingReservationController.IStandingReservationController {
+	return standingReservationController.NewStandingReservationController(r.service)
+}
+
+func (r *Registry) GetEvent() eventController.IEventController {
+	return eventController.NewEventController(r.service)
+}
@@ -0,0 +1,25 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type BlackoutRequest struct {
+	FieldID   string `json:"fieldID"`
+	Name      string `json:"name" validate:"required"`
+	StartDate string `json:"startDate" validate:"required"`
+	EndDate   string `json:"endDate" validate:"required"`
+}
+
+type BlackoutResponse struct {
+	UUID      uuid.UUID                       `json:"uuid"`
+	FieldID   *uuid.UUID                      `json:"fieldID"`
+	FieldName string                          `json:"fieldName,omitempty"`
+	Name      string                          `json:"name"`
+	StartDate string                          `json:"startDate"`
+	EndDate   string                          `json:"endDate"`
+	Conflicts []FieldScheduleConflictResponse `json:"conflicts,omitempty"`
+	CreatedAt *time.Time                      `json:"createdAt"`
+	UpdatedAt *time.Time                      `json:"updatedAt"`
+}
@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type EventRequest struct {
+	Name      string   `json:"name" validate:"required,max=100"`
+	FieldIDs  []string `json:"fieldIDs" validate:"required,min=1"`
+	StartDate string   `json:"startDate" validate:"required"`
+	EndDate   string   `json:"endDate" validate:"required"`
+	StartTime string   `json:"startTime"`
+	EndTime   string   `json:"endTime"`
+}
+
+type EventResponse struct {
+	UUID        uuid.UUID                       `json:"uuid"`
+	Name        string                          `json:"name"`
+	Fields      []FieldResponse                 `json:"fields"`
+	StartDate   string                          `json:"startDate"`
+	EndDate     string                          `json:"endDate"`
+	StartTime   string                          `json:"startTime"`
+	EndTime     string                          `json:"endTime"`
+	Reserved    int                             `json:"reserved,omitempty"`
+	CreatedBy   string                          `json:"createdBy"`
+	CancelledAt *time.Time                      `json:"cancelledAt"`
+	CreatedAt   *time.Time                      `json:"createdAt"`
+	UpdatedAt   *time.Time                      `json:"updatedAt"`
+	Conflicts   []FieldScheduleConflictResponse `json:"conflicts,omitempty"`
+}
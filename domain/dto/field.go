@@ -10,6 +10,9 @@ type FieldRequest struct {
 	Name         string                 `form:"name" validate:"required"`
 	Code         string                 `form:"code" validate:"required"`
 	PricePerHour int                    `form:"pricePerHour" validate:"required"`
+	TimeSetID    string                 `form:"timeSetID"`
+	ParentID     string                 `form:"parentID"`
+	BufferMinute int                    `form:"bufferMinute" validate:"min=0"`
 	Images       []multipart.FileHeader `form:"images" validate:"required"`
 }
 
@@ -17,6 +20,9 @@ type UpdateFieldRequest struct {
 	Name         string                 `form:"name" validate:"required"`
 	Code         string                 `form:"code" validate:"required"`
 	PricePerHour int                    `form:"pricePerHour" validate:"required"`
+	TimeSetID    *string                `form:"timeSetID"`
+	ParentID     *string                `form:"parentID"`
+	BufferMinute *int                   `form:"bufferMinute" validate:"omitempty,min=0"`
 	Images       []multipart.FileHeader `form:"images"`
 }
 
@@ -25,6 +31,9 @@ type FieldResponse struct {
 	Code         string     `json:"code"`
 	Name         string     `json:"name"`
 	PricePerHour int        `json:"pricePerHour"`
+	TimeSetID    *uuid.UUID `json:"timeSetID"`
+	ParentID     *uuid.UUID `json:"parentID"`
+	BufferMinute int        `json:"bufferMinute"`
 	Images       []string   `json:"images"`
 	CreatedAt    *time.Time `json:"createdAt"`
 	UpdatedAt    *time.Time `json:"updatedAt"`
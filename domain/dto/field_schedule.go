@@ -13,7 +13,32 @@ type FieldScheduleRequest struct {
 }
 
 type GenerateFieldScheduleForOneMonthRequest struct {
-	FieldID string `json:"fieldID" validate:"required"`
+	FieldID      string `json:"fieldID" validate:"required"`
+	StartDate    string `json:"startDate"`
+	EndDate      string `json:"endDate"`
+	NumberOfDays int    `json:"numberOfDays" validate:"omitempty,min=1"`
+	Mode         string `json:"mode" validate:"omitempty,oneof=strict skip"`
+}
+
+type GenerateFieldScheduleResponse struct {
+	StartDate   string   `json:"startDate"`
+	EndDate     string   `json:"endDate"`
+	Created     int      `json:"created"`
+	Skipped     int      `json:"skipped"`
+	ClosedDates []string `json:"closedDates"`
+}
+
+type GenerateScheduleHorizonResponse struct {
+	Acquired bool                                   `json:"acquired"`
+	Fields   []GenerateFieldScheduleHorizonResponse `json:"fields"`
+}
+
+type GenerateFieldScheduleHorizonResponse struct {
+	FieldID   uuid.UUID `json:"fieldID"`
+	FieldName string    `json:"fieldName"`
+	Created   int       `json:"created"`
+	Skipped   int       `json:"skipped"`
+	Error     error     `json:"-"`
 }
 
 type UpdateFieldScheduleRequest struct {
@@ -22,26 +47,110 @@ type UpdateFieldScheduleRequest struct {
 }
 
 type UpdateStatusFieldScheduleRequest struct {
+	FieldScheduleIDs   []string `json:"fieldScheduleIDs" validate:"required,min=1"`
+	QuoteToken         string   `json:"quoteToken"`
+	RequireConsecutive bool     `json:"requireConsecutive"`
+	UserID             string   `json:"userID" validate:"required,uuid"`
+}
+
+type HoldFieldScheduleRequest struct {
+	FieldScheduleIDs   []string `json:"fieldScheduleIDs" validate:"required,min=1"`
+	RequireConsecutive bool     `json:"requireConsecutive"`
+	UserID             string   `json:"userID" validate:"required,uuid"`
+}
+
+type UpdateStatusFieldScheduleResponse struct {
+	FieldScheduleIDs []string                        `json:"fieldScheduleIDs"`
+	Conflicts        []FieldScheduleConflictResponse `json:"conflicts,omitempty"`
+}
+
+type HoldFieldScheduleResponse struct {
+	FieldScheduleIDs []string                        `json:"fieldScheduleIDs"`
+	HeldUntil        *time.Time                      `json:"heldUntil,omitempty"`
+	Conflicts        []FieldScheduleConflictResponse `json:"conflicts,omitempty"`
+}
+
+type QuoteFieldScheduleRequest struct {
+	FieldScheduleIDs []string `json:"fieldScheduleIDs" validate:"required,min=1"`
+}
+
+type QuoteFieldScheduleResponse struct {
+	FieldID           uuid.UUID                       `json:"fieldID"`
+	FieldName         string                          `json:"fieldName"`
+	Items             []QuoteItemResponse             `json:"items"`
+	Subtotal          float64                         `json:"subtotal"`
+	SubtotalFormatted string                          `json:"subtotalFormatted"`
+	PricingRules      []string                        `json:"pricingRules"`
+	QuoteToken        string                          `json:"quoteToken"`
+	ExpiredAt         time.Time                       `json:"expiredAt"`
+	Conflicts         []FieldScheduleConflictResponse `json:"conflicts,omitempty"`
+}
+
+type QuoteItemResponse struct {
+	UUID           uuid.UUID `json:"uuid"`
+	Date           string    `json:"date"`
+	Time           string    `json:"time"`
+	DurationMinute int       `json:"durationMinute"`
+	PricePerHour   int       `json:"pricePerHour"`
+	Price          float64   `json:"price"`
+	PriceFormatted string    `json:"priceFormatted"`
+	PricingRule    string    `json:"pricingRule,omitempty"`
+}
+
+type FieldScheduleConflictResponse struct {
+	UUID      uuid.UUID                         `json:"uuid"`
+	FieldName string                            `json:"fieldName,omitempty"`
+	Date      string                            `json:"date"`
+	Time      string                            `json:"time"`
+	Status    constants.FieldScheduleStatusName `json:"status"`
+}
+
+type ReleaseFieldScheduleRequest struct {
 	FieldScheduleIDs []string `json:"fieldScheduleIDs" validate:"required"`
+	Reason           string   `json:"reason" validate:"required"`
+}
+
+type MaintenanceFieldScheduleRequest struct {
+	FieldID   string `json:"fieldID" validate:"required"`
+	StartDate string `json:"startDate" validate:"required"`
+	EndDate   string `json:"endDate" validate:"required"`
+	StartTime string `json:"startTime"`
+	EndTime   string `json:"endTime"`
+	Note      string `json:"note" validate:"required"`
+}
+
+type MaintenanceFieldScheduleResponse struct {
+	FieldScheduleIDs []uuid.UUID                     `json:"fieldScheduleIDs"`
+	Conflicts        []FieldScheduleConflictResponse `json:"conflicts,omitempty"`
 }
 
 type FieldScheduleResponse struct {
-	UUID         uuid.UUID                         `json:"uuid"`
-	FieldName    string                            `json:"fieldName"`
-	PricePerHour int                               `json:"pricePerHour"`
-	Date         string                            `json:"date"`
-	Status       constants.FieldScheduleStatusName `json:"status"`
-	Time         string                            `json:"time"`
-	CreatedAt    *time.Time                        `json:"createdAt"`
-	UpdatedAt    *time.Time                        `json:"updatedAt"`
+	UUID           uuid.UUID                         `json:"uuid"`
+	FieldName      string                            `json:"fieldName"`
+	PricePerHour   int                               `json:"pricePerHour"`
+	DurationMinute int                               `json:"durationMinute"`
+	Price          float64                           `json:"price"`
+	PriceFormatted string                            `json:"priceFormatted"`
+	PricingRule    string                            `json:"pricingRule,omitempty"`
+	Date           string                            `json:"date"`
+	Status         constants.FieldScheduleStatusName `json:"status"`
+	Time           string                            `json:"time"`
+	CreatedAt      *time.Time                        `json:"createdAt"`
+	UpdatedAt      *time.Time                        `json:"updatedAt"`
 }
 
 type FieldScheduleForBookingResponse struct {
-	UUID         uuid.UUID                         `json:"uuid"`
-	PricePerHour string                            `json:"pricePerHour"`
-	Date         string                            `json:"date"`
-	Status       constants.FieldScheduleStatusName `json:"status"`
-	Time         string                            `json:"time"`
+	UUID           uuid.UUID                         `json:"uuid"`
+	PricePerHour   string                            `json:"pricePerHour"`
+	DurationMinute int                               `json:"durationMinute"`
+	Price          float64                           `json:"price"`
+	PriceFormatted string                            `json:"priceFormatted"`
+	PricingRule    string                            `json:"pricingRule,omitempty"`
+	Date           string                            `json:"date"`
+	Status         constants.FieldScheduleStatusName `json:"status"`
+	Time           string                            `json:"time"`
+	BookableTime   string                            `json:"bookableTime"`
+	BufferMinute   int                               `json:"bufferMinute"`
 }
 
 type FieldScheduleRequestParam struct {
@@ -54,3 +163,38 @@ type FieldScheduleRequestParam struct {
 type FieldScheduleByFieldIDAndDateRequestParam struct {
 	Date string `form:"date" validate:"required"`
 }
+
+type FieldScheduleSearchRequestParam struct {
+	Date              string `form:"date" validate:"required"`
+	StartTime         string `form:"startTime"`
+	EndTime           string `form:"endTime"`
+	MinDurationMinute int    `form:"minDurationMinute" validate:"omitempty,min=1"`
+	Name              string `form:"name"`
+	MaxPricePerHour   int    `form:"maxPricePerHour" validate:"omitempty,min=1"`
+}
+
+type FieldScheduleSearchResponse struct {
+	FieldID      uuid.UUID                         `json:"fieldID"`
+	FieldCode    string                            `json:"fieldCode"`
+	FieldName    string                            `json:"fieldName"`
+	PricePerHour int                               `json:"pricePerHour"`
+	Images       []string                          `json:"images"`
+	Slots        []FieldScheduleForBookingResponse `json:"slots"`
+}
+
+type FieldScheduleCalendarRequestParam struct {
+	Month     string `form:"month" validate:"required_without=StartDate"`
+	StartDate string `form:"startDate" validate:"required_without=Month"`
+	EndDate   string `form:"endDate" validate:"required_with=StartDate"`
+}
+
+type FieldScheduleCalendarResponse struct {
+	Date        string `json:"date"`
+	Total       int    `json:"total"`
+	Available   int    `json:"available"`
+	Held        int    `json:"held"`
+	Booked      int    `json:"booked"`
+	Blocked     int    `json:"blocked"`
+	Blackout    bool   `json:"blackout"`
+	FullyBooked bool   `json:"fullyBooked"`
+}
@@ -0,0 +1,76 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type PromoRequest struct {
+	Code          string   `json:"code" validate:"required,max=50"`
+	Name          string   `json:"name" validate:"required"`
+	DiscountType  string   `json:"discountType" validate:"required,oneof=percentage fixed"`
+	DiscountValue int      `json:"discountValue" validate:"required,min=1"`
+	MaxDiscount   *int     `json:"maxDiscount" validate:"omitempty,min=1"`
+	StartDate     string   `json:"startDate" validate:"required"`
+	EndDate       string   `json:"endDate" validate:"required"`
+	Weekdays      []int    `json:"weekdays" validate:"omitempty,dive,min=0,max=6"`
+	StartTime     string   `json:"startTime"`
+	EndTime       string   `json:"endTime"`
+	FieldIDs      []string `json:"fieldIDs"`
+	UsageLimit    *int     `json:"usageLimit" validate:"omitempty,min=1"`
+	PerUserLimit  *int     `json:"perUserLimit" validate:"omitempty,min=1"`
+	FirstBooking  bool     `json:"firstBooking"`
+}
+
+type PromoResponse struct {
+	UUID          uuid.UUID       `json:"uuid"`
+	Code          string          `json:"code"`
+	Name          string          `json:"name"`
+	DiscountType  string          `json:"discountType"`
+	DiscountValue int             `json:"discountValue"`
+	MaxDiscount   *int            `json:"maxDiscount"`
+	StartDate     string          `json:"startDate"`
+	EndDate       string          `json:"endDate"`
+	Weekdays      []int           `json:"weekdays"`
+	StartTime     *string         `json:"startTime"`
+	EndTime       *string         `json:"endTime"`
+	Fields        []FieldResponse `json:"fields"`
+	UsageLimit    *int            `json:"usageLimit"`
+	PerUserLimit  *int            `json:"perUserLimit"`
+	FirstBooking  bool            `json:"firstBooking"`
+	CreatedAt     *time.Time      `json:"createdAt"`
+	UpdatedAt     *time.Time      `json:"updatedAt"`
+}
+
+type EvaluatePromoRequest struct {
+	Code             string   `json:"code" validate:"required"`
+	UserID           string   `json:"userID" validate:"required,uuid"`
+	FieldScheduleIDs []string `json:"fieldScheduleIDs" validate:"required,min=1"`
+}
+
+type RedeemPromoRequest struct {
+	Code             string   `json:"code" validate:"required"`
+	UserID           string   `json:"userID" validate:"required,uuid"`
+	FieldScheduleIDs []string `json:"fieldScheduleIDs" validate:"required,min=1"`
+	Reference        string   `json:"reference" validate:"required,max=100"`
+}
+
+type EvaluatePromoResponse struct {
+	Code              string              `json:"code"`
+	Name              string              `json:"name"`
+	Items             []PromoItemResponse `json:"items"`
+	Subtotal          float64             `json:"subtotal"`
+	EligibleSubtotal  float64             `json:"eligibleSubtotal"`
+	Discount          float64             `json:"discount"`
+	DiscountFormatted string              `json:"discountFormatted"`
+	Total             float64             `json:"total"`
+	TotalFormatted    string              `json:"totalFormatted"`
+}
+
+type PromoItemResponse struct {
+	UUID     uuid.UUID `json:"uuid"`
+	Date     string    `json:"date"`
+	Time     string    `json:"time"`
+	Price    float64   `json:"price"`
+	Eligible bool      `json:"eligible"`
+}
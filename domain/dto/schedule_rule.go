@@ -0,0 +1,30 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type ScheduleRuleRequest struct {
+	FieldID   string   `json:"fieldID" validate:"required"`
+	Weekdays  []int    `json:"weekdays" validate:"required,min=1,dive,min=0,max=6"`
+	TimeIDs   []string `json:"timeIDs" validate:"required,min=1"`
+	StartDate string   `json:"startDate" validate:"required"`
+	EndDate   string   `json:"endDate" validate:"required"`
+}
+
+type ScheduleRuleResponse struct {
+	UUID      uuid.UUID      `json:"uuid"`
+	FieldID   uuid.UUID      `json:"fieldID"`
+	FieldName string         `json:"fieldName"`
+	Weekdays  []int          `json:"weekdays"`
+	Times     []TimeResponse `json:"times"`
+	StartDate string         `json:"startDate"`
+	EndDate   string         `json:"endDate"`
+	CreatedAt *time.Time     `json:"createdAt"`
+	UpdatedAt *time.Time     `json:"updatedAt"`
+}
+
+type ScheduleRuleRequestParam struct {
+	FieldID string `form:"fieldID"`
+}
@@ -0,0 +1,39 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type StandingReservationRequest struct {
+	FieldID   string `json:"fieldID" validate:"required"`
+	TimeID    string `json:"timeID" validate:"required"`
+	Weekday   *int   `json:"weekday" validate:"required,min=0,max=6"`
+	StartDate string `json:"startDate" validate:"required"`
+	EndDate   string `json:"endDate" validate:"required"`
+	Name      string `json:"name" validate:"required,max=100"`
+	OwnerID   string `json:"ownerID" validate:"omitempty,uuid"`
+}
+
+type StandingReservationResponse struct {
+	UUID        uuid.UUID                             `json:"uuid"`
+	FieldID     uuid.UUID                             `json:"fieldID"`
+	FieldName   string                                `json:"fieldName"`
+	TimeID      uuid.UUID                             `json:"timeID"`
+	Time        string                                `json:"time"`
+	Weekday     int                                   `json:"weekday"`
+	StartDate   string                                `json:"startDate"`
+	EndDate     string                                `json:"endDate"`
+	Name        string                                `json:"name"`
+	OwnerID     uuid.UUID                             `json:"ownerID"`
+	Reserved    int                                   `json:"reserved,omitempty"`
+	CancelledAt *time.Time                            `json:"cancelledAt"`
+	CreatedAt   *time.Time                            `json:"createdAt"`
+	UpdatedAt   *time.Time                            `json:"updatedAt"`
+	Conflicts   []StandingReservationConflictResponse `json:"conflicts,omitempty"`
+}
+
+type StandingReservationConflictResponse struct {
+	Date   string `json:"date"`
+	Reason string `json:"reason"`
+}
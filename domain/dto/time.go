@@ -6,12 +6,20 @@ import (
 )
 
 type TimeRequest struct {
+	TimeSetID string `json:"timeSetID"`
 	StartTime string `json:"startTime" validate:"required"`
 	EndTime   string `json:"endTime" validate:"required"`
 }
 
+type UpdateTimeRequest struct {
+	StartTime     string `json:"startTime" validate:"required"`
+	EndTime       string `json:"endTime" validate:"required"`
+	MoveSchedules bool   `json:"moveSchedules"`
+}
+
 type TimeResponse struct {
 	UUID      uuid.UUID  `json:"uuid"`
+	TimeSetID *uuid.UUID `json:"timeSetID"`
 	StartTime string     `json:"startTime"`
 	EndTime   string     `json:"endTime" validate:"required"`
 	CreatedAt *time.Time `json:"createdAt"`
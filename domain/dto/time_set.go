@@ -0,0 +1,17 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type TimeSetRequest struct {
+	Name string `json:"name" validate:"required"`
+}
+
+type TimeSetResponse struct {
+	UUID      uuid.UUID  `json:"uuid"`
+	Name      string     `json:"name"`
+	CreatedAt *time.Time `json:"createdAt"`
+	UpdatedAt *time.Time `json:"updatedAt"`
+}
@@ -0,0 +1,23 @@
+package dto
+
+import (
+	"field-service/constants"
+	"github.com/google/uuid"
+	"time"
+)
+
+type WaitlistRequest struct {
+	FieldScheduleID string `json:"fieldScheduleID" validate:"required"`
+}
+
+type WaitlistResponse struct {
+	UUID            uuid.UUID                    `json:"uuid"`
+	FieldScheduleID uuid.UUID                    `json:"fieldScheduleID"`
+	FieldName       string                       `json:"fieldName"`
+	Date            string                       `json:"date"`
+	Time            string                       `json:"time"`
+	Status          constants.WaitlistStatusName `json:"status"`
+	OfferedUntil    *time.Time                   `json:"offeredUntil"`
+	CreatedAt       *time.Time                   `json:"createdAt"`
+	UpdatedAt       *time.Time                   `json:"updatedAt"`
+}
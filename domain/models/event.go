@@ -0,0 +1,23 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+type Event struct {
+	ID          uint      `gorm:"primaryKey;autoIncrement"`
+	UUID        uuid.UUID `gorm:"type:uuid;not null"`
+	Name        string    `gorm:"type:varchar(100);not null"`
+	StartDate   time.Time `gorm:"type:date;not null"`
+	EndDate     time.Time `gorm:"type:date;not null"`
+	StartTime   string    `gorm:"type:time without time zone;not null"`
+	EndTime     string    `gorm:"type:time without time zone;not null"`
+	CreatedBy   string    `gorm:"type:varchar(100);not null"`
+	CancelledAt *time.Time
+	CreatedAt   *time.Time
+	UpdatedAt   *time.Time
+	DeletedAt   *gorm.DeletedAt
+	Fields      []Field `gorm:"many2many:event_fields;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
@@ -8,14 +8,20 @@ import (
 )
 
 type Field struct {
-	ID            uint           `gorm:"primaryKey;autoIncrement" `
-	UUID          uuid.UUID      `gorm:"type:uuid;not null"`
-	Code          string         `gorm:"type:varchar(15);not null"`
-	Name          string         `gorm:"type:varchar(100);not null"`
-	PricePerHour  int            `gorm:"type:int;not null"`
-	Images        pq.StringArray `gorm:"type:text[];not null"`
-	CreatedAt     *time.Time
-	UpdatedAt     *time.Time
-	DeletedAt     *gorm.DeletedAt
-	FieldSchedule []FieldSchedule `gorm:"foreignKey:field_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	ID             uint           `gorm:"primaryKey;autoIncrement" `
+	UUID           uuid.UUID      `gorm:"type:uuid;not null"`
+	Code           string         `gorm:"type:varchar(15);not null"`
+	Name           string         `gorm:"type:varchar(100);not null"`
+	PricePerHour   int            `gorm:"type:int;not null"`
+	TimeSetID      *uint          `gorm:"type:int"`
+	ParentID       *uint          `gorm:"type:int;index"`
+	BufferMinute   int            `gorm:"type:int;not null;default:0"`
+	ScheduledUntil *time.Time     `gorm:"type:date"`
+	Images         pq.StringArray `gorm:"type:text[];not null"`
+	CreatedAt      *time.Time
+	UpdatedAt      *time.Time
+	DeletedAt      *gorm.DeletedAt
+	FieldSchedule  []FieldSchedule `gorm:"foreignKey:field_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	TimeSet        *TimeSet        `gorm:"foreignKey:time_set_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;"`
+	Parent         *Field          `gorm:"foreignKey:parent_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
 }
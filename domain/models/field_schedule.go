@@ -8,15 +8,34 @@ import (
 )
 
 type FieldSchedule struct {
-	ID        uint                          `gorm:"primaryKey;autoIncrement"`
-	UUID      uuid.UUID                     `gorm:"type:uuid;not null"`
-	FieldID   uint                          `gorm:"type:int;not null"`
-	TimeID    uint                          `gorm:"type:int;not null"`
-	Date      time.Time                     `gorm:"type:date;not null"`
-	Status    constants.FieldScheduleStatus `gorm:"type:int;not null"`
-	CreatedAt *time.Time
-	UpdatedAt *time.Time
-	DeletedAt *gorm.DeletedAt
-	Field     Field `gorm:"foreignKey:field_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
-	Time      Time  `gorm:"foreignKey:time_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	ID                    uint                          `gorm:"primaryKey;autoIncrement"`
+	UUID                  uuid.UUID                     `gorm:"type:uuid;not null"`
+	FieldID               uint                          `gorm:"type:int;not null;uniqueIndex:idx_field_schedules_field_date_time,priority:1,where:deleted_at IS NULL"`
+	TimeID                uint                          `gorm:"type:int;not null;uniqueIndex:idx_field_schedules_field_date_time,priority:3,where:deleted_at IS NULL"`
+	Date                  time.Time                     `gorm:"type:date;not null;uniqueIndex:idx_field_schedules_field_date_time,priority:2,where:deleted_at IS NULL"`
+	Status                constants.FieldScheduleStatus `gorm:"type:int;not null"`
+	HeldUntil             *time.Time                    `gorm:"type:timestamp"`
+	HeldBy                *uuid.UUID                    `gorm:"type:uuid"`
+	BookedBy              *uuid.UUID                    `gorm:"type:uuid;index"`
+	StandingReservationID *uint                         `gorm:"type:int;index"`
+	EventID               *uint                         `gorm:"type:int;index"`
+	CreatedByEvent        bool                          `gorm:"type:boolean;not null;default:false"`
+	PricePerHour          *int                          `gorm:"type:int"`
+	Price                 *float64                      `gorm:"type:numeric(12,2)"`
+	PricingRuleName       *string                       `gorm:"type:varchar(100)"`
+	CreatedAt             *time.Time
+	UpdatedAt             *time.Time
+	DeletedAt             *gorm.DeletedAt
+	Field                 Field `gorm:"foreignKey:field_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Time                  Time  `gorm:"foreignKey:time_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;"`
+}
+
+// FieldScheduleSummary adalah hasil agregasi jumlah schedule per tanggal, bukan tabel.
+type FieldScheduleSummary struct {
+	Date      time.Time
+	Total     int
+	Available int
+	Held      int
+	Booked    int
+	Blocked   int
 }
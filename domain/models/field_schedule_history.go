@@ -0,0 +1,17 @@
+package models
+
+import (
+	"field-service/constants"
+	"time"
+)
+
+type FieldScheduleHistory struct {
+	ID              uint                          `gorm:"primaryKey;autoIncrement"`
+	FieldScheduleID uint                          `gorm:"type:int;not null"`
+	Status          constants.FieldScheduleStatus `gorm:"type:int;not null"`
+	Reason          string                        `gorm:"type:text;not null"`
+	CreatedBy       string                        `gorm:"type:varchar(100);not null"`
+	CreatedAt       *time.Time
+	UpdatedAt       *time.Time
+	FieldSchedule   FieldSchedule `gorm:"foreignKey:field_schedule_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+	"time"
+)
+
+type PricingRule struct {
+	ID           uint          `gorm:"primaryKey;autoIncrement"`
+	UUID         uuid.UUID     `gorm:"type:uuid;not null"`
+	FieldID      uint          `gorm:"type:int;not null"`
+	Name         string        `gorm:"type:varchar(100);not null"`
+	Weekdays     pq.Int32Array `gorm:"type:integer[];not null"`
+	StartTime    string        `gorm:"type:time without time zone;not null"`
+	EndTime      string        `gorm:"type:time without time zone;not null"`
+	StartDate    *time.Time    `gorm:"type:date"`
+	EndDate      *time.Time    `gorm:"type:date"`
+	PricePerHour int           `gorm:"type:int;not null"`
+	Priority     int           `gorm:"type:int;not null;default:0"`
+	CreatedAt    *time.Time
+	UpdatedAt    *time.Time
+	DeletedAt    *gorm.DeletedAt
+	Field        Field `gorm:"foreignKey:field_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+	"time"
+)
+
+type Promo struct {
+	ID            uint          `gorm:"primaryKey;autoIncrement"`
+	UUID          uuid.UUID     `gorm:"type:uuid;not null"`
+	Code          string        `gorm:"type:varchar(50);not null;uniqueIndex:idx_promos_code,where:deleted_at IS NULL"`
+	Name          string        `gorm:"type:varchar(100);not null"`
+	DiscountType  string        `gorm:"type:varchar(20);not null"`
+	DiscountValue int           `gorm:"type:int;not null"`
+	MaxDiscount   *int          `gorm:"type:int"`
+	StartDate     time.Time     `gorm:"type:date;not null"`
+	EndDate       time.Time     `gorm:"type:date;not null"`
+	Weekdays      pq.Int32Array `gorm:"type:integer[]"`
+	StartTime     *string       `gorm:"type:time without time zone"`
+	EndTime       *string       `gorm:"type:time without time zone"`
+	UsageLimit    *int          `gorm:"type:int"`
+	PerUserLimit  *int          `gorm:"type:int"`
+	FirstBooking  bool          `gorm:"not null;default:false"`
+	CreatedAt     *time.Time
+	UpdatedAt     *time.Time
+	DeletedAt     *gorm.DeletedAt
+	Fields        []Field `gorm:"many2many:promo_fields;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+type PromoUsage struct {
+	ID        uint    `gorm:"primaryKey;autoIncrement"`
+	PromoID   uint    `gorm:"type:int;not null;uniqueIndex:idx_promo_usages_promo_reference,priority:1"`
+	UserID    string  `gorm:"type:varchar(100);not null;index"`
+	Reference string  `gorm:"type:varchar(100);not null;uniqueIndex:idx_promo_usages_promo_reference,priority:2"`
+	Discount  float64 `gorm:"type:numeric(12,2);not null"`
+	CreatedAt *time.Time
+	UpdatedAt *time.Time
+	Promo     Promo `gorm:"foreignKey:promo_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+type PromoUsageSchedule struct {
+	ID              uint   `gorm:"primaryKey;autoIncrement"`
+	PromoUsageID    uint   `gorm:"type:int;not null;index"`
+	FieldScheduleID uint   `gorm:"type:int;not null;uniqueIndex:idx_promo_usage_schedules_schedule_reference,priority:1"`
+	Reference       string `gorm:"type:varchar(100);not null;uniqueIndex:idx_promo_usage_schedules_schedule_reference,priority:2"`
+	CreatedAt       *time.Time
+	UpdatedAt       *time.Time
+	PromoUsage      PromoUsage    `gorm:"foreignKey:promo_usage_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	FieldSchedule   FieldSchedule `gorm:"foreignKey:field_schedule_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
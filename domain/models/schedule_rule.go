@@ -0,0 +1,22 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+	"time"
+)
+
+type ScheduleRule struct {
+	ID        uint          `gorm:"primaryKey;autoIncrement"`
+	UUID      uuid.UUID     `gorm:"type:uuid;not null"`
+	FieldID   uint          `gorm:"type:int;not null"`
+	Weekdays  pq.Int32Array `gorm:"type:integer[];not null"`
+	StartDate time.Time     `gorm:"type:date;not null"`
+	EndDate   time.Time     `gorm:"type:date;not null"`
+	CreatedAt *time.Time
+	UpdatedAt *time.Time
+	DeletedAt *gorm.DeletedAt
+	Field     Field  `gorm:"foreignKey:field_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Times     []Time `gorm:"many2many:schedule_rule_times;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
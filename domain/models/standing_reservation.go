@@ -0,0 +1,25 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+type StandingReservation struct {
+	ID          uint      `gorm:"primaryKey;autoIncrement"`
+	UUID        uuid.UUID `gorm:"type:uuid;not null"`
+	FieldID     uint      `gorm:"type:int;not null;index"`
+	TimeID      uint      `gorm:"type:int;not null"`
+	Weekday     int       `gorm:"type:int;not null"`
+	StartDate   time.Time `gorm:"type:date;not null"`
+	EndDate     time.Time `gorm:"type:date;not null"`
+	Name        string    `gorm:"type:varchar(100);not null"`
+	OwnerID     uuid.UUID `gorm:"type:uuid;not null;index"`
+	CancelledAt *time.Time
+	CreatedAt   *time.Time
+	UpdatedAt   *time.Time
+	DeletedAt   *gorm.DeletedAt
+	Field       Field `gorm:"foreignKey:field_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Time        Time  `gorm:"foreignKey:time_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;"`
+}
@@ -9,9 +9,11 @@ import (
 type Time struct {
 	ID        uint      `gorm:"primaryKey;autoIncrement"`
 	UUID      uuid.UUID `gorm:"type:uuid;not null"`
+	TimeSetID *uint     `gorm:"type:int"`
 	StartTime string    `gorm:"type:time without time zone;not null"`
 	EndTime   string    `gorm:"type:time without time zone;not null"`
 	CreatedAt *time.Time
 	UpdatedAt *time.Time
 	DeletedAt *gorm.DeletedAt
+	TimeSet   *TimeSet `gorm:"foreignKey:time_set_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;"`
 }
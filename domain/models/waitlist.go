@@ -0,0 +1,21 @@
+package models
+
+import (
+	"field-service/constants"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+type Waitlist struct {
+	ID              uint                     `gorm:"primaryKey;autoIncrement"`
+	UUID            uuid.UUID                `gorm:"type:uuid;not null"`
+	FieldScheduleID uint                     `gorm:"type:int;not null;index"`
+	UserID          uuid.UUID                `gorm:"type:uuid;not null;index"`
+	Status          constants.WaitlistStatus `gorm:"type:int;not null"`
+	OfferedUntil    *time.Time               `gorm:"type:timestamp"`
+	CreatedAt       *time.Time
+	UpdatedAt       *time.Time
+	DeletedAt       *gorm.DeletedAt
+	FieldSchedule   FieldSchedule `gorm:"foreignKey:field_schedule_id;references:id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
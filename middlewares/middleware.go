@@ -103,6 +103,8 @@ func CheckRole(roles []string, client clients.IClientRegistry) gin.HandlerFunc {
 			responseUnauthorized(c, errConstant.ErrUnauthorized.Error())
 			return
 		}
+
+		c.Set(constants.User, user)
 		c.Next()
 	}
 }
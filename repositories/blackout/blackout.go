@@ -0,0 +1,130 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	errWrap "field-service/common/error"
+	errConstant "field-service/constants/error"
+	errBlackout "field-service/constants/error/blackout"
+	"field-service/domain/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type BlackoutRepository struct {
+	db *gorm.DB
+}
+
+type IBlackoutRepository interface {
+	FindAll(context.Context) ([]models.Blackout, error)
+	FindAllByFieldIDAndDateRange(context.Context, int, string, string) ([]models.Blackout, error)
+	FindByUUID(context.Context, string) (*models.Blackout, error)
+	Create(context.Context, *gorm.DB, *models.Blackout) (*models.Blackout, error)
+	Update(context.Context, *gorm.DB, string, *models.Blackout) (*models.Blackout, error)
+	Delete(context.Context, string) error
+}
+
+func NewBlackoutRepository(db *gorm.DB) IBlackoutRepository {
+	return &BlackoutRepository{db: db}
+}
+
+func (b *BlackoutRepository) FindAll(ctx context.Context) ([]models.Blackout, error) {
+	var blackouts []models.Blackout
+	err := b.db.
+		WithContext(ctx).
+		Preload("Field").
+		Order("start_date desc").
+		Find(&blackouts).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return blackouts, nil
+}
+
+// FindAllByFieldIDAndDateRange mengambil blackout milik field tersebut dan blackout
+// untuk seluruh venue (field_id kosong) yang beririsan dengan rentang tanggal.
+func (b *BlackoutRepository) FindAllByFieldIDAndDateRange(
+	ctx context.Context,
+	fieldID int,
+	startDate, endDate string,
+) ([]models.Blackout, error) {
+	var blackouts []models.Blackout
+	err := b.db.
+		WithContext(ctx).
+		Where("field_id = ? OR field_id IS NULL", fieldID).
+		Where("start_date <= ?", endDate).
+		Where("end_date >= ?", startDate).
+		Find(&blackouts).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return blackouts, nil
+}
+
+func (b *BlackoutRepository) FindByUUID(ctx context.Context, uuid string) (*models.Blackout, error) {
+	var blackout models.Blackout
+	err := b.db.
+		WithContext(ctx).
+		Preload("Field").
+		Where("uuid = ?", uuid).
+		First(&blackout).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapError(errBlackout.ErrBlackoutNotFound)
+		}
+
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &blackout, nil
+}
+
+func (b *BlackoutRepository) Create(ctx context.Context, tx *gorm.DB, req *models.Blackout) (*models.Blackout, error) {
+	blackout := models.Blackout{
+		UUID:      uuid.New(),
+		FieldID:   req.FieldID,
+		Name:      req.Name,
+		StartDate: req.StartDate,
+		EndDate:   req.EndDate,
+	}
+
+	err := tx.WithContext(ctx).Create(&blackout).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &blackout, nil
+}
+
+func (b *BlackoutRepository) Update(ctx context.Context, tx *gorm.DB, uuid string, req *models.Blackout) (*models.Blackout, error) {
+	blackout, err := b.FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	blackout.FieldID = req.FieldID
+	blackout.Name = req.Name
+	blackout.StartDate = req.StartDate
+	blackout.EndDate = req.EndDate
+
+	err = tx.WithContext(ctx).Omit("Field").Save(blackout).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return blackout, nil
+}
+
+func (b *BlackoutRepository) Delete(ctx context.Context, uuid string) error {
+	err := b.db.WithContext(ctx).Where("uuid = ?", uuid).Delete(&models.Blackout{}).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	errWrap "field-service/common/error"
+	errConstant "field-service/constants/error"
+	errEvent "field-service/constants/error/event"
+	"field-service/domain/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+type EventRepository struct {
+	db *gorm.DB
+}
+
+type IEventRepository interface {
+	FindAll(context.Context) ([]models.Event, error)
+	FindByUUID(context.Context, string) (*models.Event, error)
+	Create(context.Context, *gorm.DB, *models.Event) (*models.Event, error)
+	Cancel(context.Context, *gorm.DB, uint, time.Time) error
+}
+
+func NewEventRepository(db *gorm.DB) IEventRepository {
+	return &EventRepository{db: db}
+}
+
+func (e *EventRepository) FindAll(ctx context.Context) ([]models.Event, error) {
+	var events []models.Event
+	err := e.db.
+		WithContext(ctx).
+		Preload("Fields").
+		Order("start_date desc").
+		Find(&events).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return events, nil
+}
+
+func (e *EventRepository) FindByUUID(ctx context.Context, uuid string) (*models.Event, error) {
+	var event models.Event
+	err := e.db.
+		WithContext(ctx).
+		Preload("Fields").
+		Where("uuid = ?", uuid).
+		First(&event).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapError(errEvent.ErrEventNotFound)
+		}
+
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &event, nil
+}
+
+func (e *EventRepository) Create(ctx context.Context, tx *gorm.DB, req *models.Event) (*models.Event, error) {
+	event := *req
+	event.UUID = uuid.New()
+
+	err := tx.WithContext(ctx).Omit("Fields.*").Create(&event).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &event, nil
+}
+
+func (e *EventRepository) Cancel(ctx context.Context, tx *gorm.DB, id uint, cancelledAt time.Time) error {
+	err := tx.
+		WithContext(ctx).
+		Model(&models.Event{}).
+		Where("id = ?", id).
+		Update("cancelled_at", cancelledAt).
+		Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"time"
 )
 
 type FieldRepository struct {
@@ -22,8 +23,12 @@ type IFieldRepository interface {
 	FindAllWithoutPagination(context.Context) ([]models.Field, error)
 	FindByUUID(context.Context, string) (*models.Field, error)
 	Create(context.Context, *models.Field) (*models.Field, error)
-	Update(context.Context, string, *models.Field) (*models.Field, error)
+	Update(context.Context, *gorm.DB, string, *models.Field) (*models.Field, error)
 	Delete(context.Context, string) error
+	CountByParentID(context.Context, uint) (int64, error)
+	FindAllLinkedByIDs(context.Context, *gorm.DB, []uint) ([]models.Field, error)
+	FindMaxBufferMinuteByTimeSetID(context.Context, *uint) (int, error)
+	UpdateScheduledUntil(context.Context, *gorm.DB, uint, time.Time) error
 }
 
 func NewFieldRepository(db *gorm.DB) IFieldRepository {
@@ -49,6 +54,8 @@ func (f *FieldRepository) FindAllWithPagination(ctx context.Context, param *dto.
 	offset := (param.Page) - 1*limit
 	err := f.db.
 		WithContext(ctx).
+		Preload("TimeSet").
+		Preload("Parent").
 		Limit(limit).
 		Offset(offset).
 		Order(sort).
@@ -74,6 +81,8 @@ func (f *FieldRepository) FindAllWithoutPagination(ctx context.Context) ([]model
 	var fields []models.Field
 	err := f.db.
 		WithContext(ctx).
+		Preload("TimeSet").
+		Preload("Parent").
 		Find(&fields).
 		Error
 	if err != nil {
@@ -87,6 +96,8 @@ func (f *FieldRepository) FindByUUID(ctx context.Context, uuid string) (*models.
 	var field models.Field
 	err := f.db.
 		WithContext(ctx).
+		Preload("TimeSet").
+		Preload("Parent").
 		Where("uuid = ?", uuid).
 		First(&field).
 		Error
@@ -108,6 +119,9 @@ func (f *FieldRepository) Create(ctx context.Context, req *models.Field) (*model
 		Name:         req.Name,
 		Images:       req.Images,
 		PricePerHour: req.PricePerHour,
+		TimeSetID:    req.TimeSetID,
+		ParentID:     req.ParentID,
+		BufferMinute: req.BufferMinute,
 	}
 
 	err := f.db.WithContext(ctx).Create(&field).Error
@@ -118,15 +132,25 @@ func (f *FieldRepository) Create(ctx context.Context, req *models.Field) (*model
 	return &field, nil
 }
 
-func (f *FieldRepository) Update(ctx context.Context, uuid string, req *models.Field) (*models.Field, error) {
+func (f *FieldRepository) Update(ctx context.Context, tx *gorm.DB, uuid string, req *models.Field) (*models.Field, error) {
 	field := models.Field{
 		Code:         req.Code,
 		Name:         req.Name,
 		Images:       req.Images,
 		PricePerHour: req.PricePerHour,
+		TimeSetID:    req.TimeSetID,
+		ParentID:     req.ParentID,
+		BufferMinute: req.BufferMinute,
 	}
 
-	err := f.db.WithContext(ctx).Where("uuid = ?", uuid).Updates(&field).Error
+	// time_set_id dan parent_id dipilih eksplisit supaya bisa dikosongkan kembali (null)
+	err := tx.
+		WithContext(ctx).
+		Model(&models.Field{}).
+		Select("code", "name", "images", "price_per_hour", "time_set_id", "parent_id", "buffer_minute", "updated_at").
+		Where("uuid = ?", uuid).
+		Updates(&field).
+		Error
 	if err != nil {
 		return nil, errWrap.WrapError(errConstant.ErrSQLError)
 	}
@@ -142,3 +166,72 @@ func (f *FieldRepository) Delete(ctx context.Context, uuid string) error {
 
 	return nil
 }
+
+func (f *FieldRepository) CountByParentID(ctx context.Context, parentID uint) (int64, error) {
+	var total int64
+	err := f.db.
+		WithContext(ctx).
+		Model(&models.Field{}).
+		Where("parent_id = ?", parentID).
+		Count(&total).
+		Error
+	if err != nil {
+		return 0, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return total, nil
+}
+
+// FindAllLinkedByIDs mengambil field dengan id tersebut beserta parent dan child-nya,
+// tx dipakai supaya perubahan parent dalam transaksi yang sama ikut terbaca.
+func (f *FieldRepository) FindAllLinkedByIDs(ctx context.Context, tx *gorm.DB, ids []uint) ([]models.Field, error) {
+	var fields []models.Field
+	err := tx.
+		WithContext(ctx).
+		Where("id IN ?", ids).
+		Or("parent_id IN ?", ids).
+		Or("id IN (?)", tx.Model(&models.Field{}).Select("parent_id").Where("id IN ?", ids)).
+		Find(&fields).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return fields, nil
+}
+
+// FindMaxBufferMinuteByTimeSetID mengambil buffer terbesar dari field yang memakai time set tersebut,
+// timeSetID kosong berarti time set default.
+func (f *FieldRepository) FindMaxBufferMinuteByTimeSetID(ctx context.Context, timeSetID *uint) (int, error) {
+	var bufferMinute int
+	query := f.db.
+		WithContext(ctx).
+		Model(&models.Field{}).
+		Select("coalesce(max(buffer_minute), 0)")
+	if timeSetID != nil {
+		query = query.Where("time_set_id = ?", *timeSetID)
+	} else {
+		query = query.Where("time_set_id IS NULL")
+	}
+
+	err := query.Scan(&bufferMinute).Error
+	if err != nil {
+		return 0, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return bufferMinute, nil
+}
+
+func (f *FieldRepository) UpdateScheduledUntil(ctx context.Context, tx *gorm.DB, id uint, scheduledUntil time.Time) error {
+	err := tx.
+		WithContext(ctx).
+		Model(&models.Field{}).
+		Where("id = ?", id).
+		Update("scheduled_until", scheduledUntil).
+		Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
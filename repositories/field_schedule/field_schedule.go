@@ -10,7 +10,11 @@ import (
 	"field-service/domain/dto"
 	"field-service/domain/models"
 	"fmt"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"strings"
+	"time"
 )
 
 type FieldScheduleRepository struct {
@@ -22,10 +26,40 @@ type IFieldScheduleRepository interface {
 	FindAllByFieldIDAndDate(context.Context, int, string) ([]models.FieldSchedule, error)
 	FindByUUID(context.Context, string) (*models.FieldSchedule, error)
 	FindByDateAndTimeID(context.Context, string, int, int) (*models.FieldSchedule, error)
-	Create(context.Context, []models.FieldSchedule) error
+	FindAllByFieldIDAndDateRange(context.Context, int, string, string) ([]models.FieldSchedule, error)
+	SummarizeByFieldIDAndDateRange(context.Context, int, string, string, time.Time) ([]models.FieldScheduleSummary, error)
+	FindAllAvailableByDate(context.Context, string, string, string, string, time.Time) ([]models.FieldSchedule, error)
+	CreateSkipExisting(context.Context, *gorm.DB, []models.FieldSchedule) (int64, error)
 	Update(context.Context, string, *models.FieldSchedule) (*models.FieldSchedule, error)
-	UpdateStatus(context.Context, constants.FieldScheduleStatus, string) error
+	FindAllByUUIDs(context.Context, []string) ([]models.FieldSchedule, error)
+	FindAllByUUIDsForUpdate(context.Context, *gorm.DB, []string) ([]models.FieldSchedule, error)
+	FindAllByDateRangeForUpdate(context.Context, *gorm.DB, *uint, string, string) ([]models.FieldSchedule, error)
+	FindAllByTimeIDForUpdate(context.Context, *gorm.DB, uint) ([]models.FieldSchedule, error)
+	FindAllByFieldIDFromDateForUpdate(context.Context, *gorm.DB, uint, string) ([]models.FieldSchedule, error)
+	FindAllByFieldIDsAndDatesForUpdate(context.Context, *gorm.DB, []uint, []string) ([]models.FieldSchedule, error)
+	UpdateStatus(context.Context, *gorm.DB, constants.FieldScheduleStatus, []uint) error
+	Book(context.Context, *gorm.DB, []uint, *uuid.UUID) error
+	CountBookedByUserID(context.Context, *gorm.DB, uuid.UUID, []uint) (int64, error)
+	UpdateTimeID(context.Context, *gorm.DB, uint, []uint) error
+	UpdatePrices(context.Context, *gorm.DB, []models.FieldSchedule) error
+	Hold(context.Context, *gorm.DB, []uint, time.Time, *uuid.UUID) error
+	FindAllFieldIDsWithExpiredHold(context.Context, time.Time) ([]uint, error)
+	FindAllExpiredHoldForUpdate(context.Context, *gorm.DB, time.Time, []uint) ([]models.FieldSchedule, error)
+	FindAllByStandingReservationIDForUpdate(context.Context, *gorm.DB, uint, string) ([]models.FieldSchedule, error)
+	UpdateStandingReservationID(context.Context, *gorm.DB, *uint, []uint) error
+	CreateWithTx(context.Context, *gorm.DB, []models.FieldSchedule) error
+	FindAllByEventIDForUpdate(context.Context, *gorm.DB, uint) ([]models.FieldSchedule, error)
+	UpdateEventID(context.Context, *gorm.DB, *uint, []uint) error
 	Delete(context.Context, string) error
+	DeleteByIDs(context.Context, *gorm.DB, []uint) error
+	TryAdvisoryLock(context.Context, *gorm.DB, int64) (bool, error)
+	AdvisoryLock(context.Context, *gorm.DB, int, uint) error
+	FindLastDateByFieldID(context.Context, uint) (*time.Time, error)
+}
+
+// unscoped dipakai saat preload time supaya schedule lama yang time-nya sudah dihapus tetap tampil
+func unscoped(db *gorm.DB) *gorm.DB {
+	return db.Unscoped()
 }
 
 func NewFieldScheduleRepository(db *gorm.DB) IFieldScheduleRepository {
@@ -52,7 +86,7 @@ func (f *FieldScheduleRepository) FindAllWithPagination(ctx context.Context, par
 	err := f.db.
 		WithContext(ctx).
 		Preload("Field").
-		Preload("Time").
+		Preload("Time", unscoped).
 		Limit(limit).
 		Offset(offset).
 		Order(sort).
@@ -79,7 +113,7 @@ func (f *FieldScheduleRepository) FindAllByFieldIDAndDate(ctx context.Context, f
 	err := f.db.
 		WithContext(ctx).
 		Preload("Field").
-		Preload("Time").
+		Preload("Time", unscoped).
 		Where("field_id = ?", fieldID).
 		Where("date = ?", date).
 		Joins("LEFT JOIN times ON field_schedules.time_id = times.id").
@@ -93,12 +127,91 @@ func (f *FieldScheduleRepository) FindAllByFieldIDAndDate(ctx context.Context, f
 	return fieldSchedule, nil
 }
 
+// SummarizeByFieldIDAndDateRange menghitung jumlah schedule per status untuk setiap tanggal dalam satu query,
+// hold yang sudah kedaluwarsa pada waktu now dihitung sebagai available.
+func (f *FieldScheduleRepository) SummarizeByFieldIDAndDateRange(
+	ctx context.Context,
+	fieldID int,
+	startDate, endDate string,
+	now time.Time,
+) ([]models.FieldScheduleSummary, error) {
+	var summaries []models.FieldScheduleSummary
+	err := f.db.
+		WithContext(ctx).
+		Model(&models.FieldSchedule{}).
+		Select(
+			"date, count(*) as total, "+
+				"count(*) filter (where status = ? or (status = ? and held_until <= ?)) as available, "+
+				"count(*) filter (where status = ? and (held_until is null or held_until > ?)) as held, "+
+				"count(*) filter (where status = ?) as booked, "+
+				"count(*) filter (where status IN ?) as blocked",
+			constants.Available, constants.Held, now,
+			constants.Held, now,
+			constants.Booked,
+			[]constants.FieldScheduleStatus{constants.Maintenance, constants.Unavailable},
+		).
+		Where("field_id = ?", fieldID).
+		Where("date BETWEEN ? AND ?", startDate, endDate).
+		Group("date").
+		Order("date asc").
+		Scan(&summaries).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return summaries, nil
+}
+
+// FindAllAvailableByDate mencari schedule available di semua field pada satu tanggal dengan satu query join,
+// startTime, endTime dan name yang kosong berarti tidak difilter.
+func (f *FieldScheduleRepository) FindAllAvailableByDate(
+	ctx context.Context,
+	date, startTime, endTime, name string,
+	now time.Time,
+) ([]models.FieldSchedule, error) {
+	var fieldSchedules []models.FieldSchedule
+	query := f.db.
+		WithContext(ctx).
+		Joins("Field").
+		Joins("Time").
+		Where(`"Field".deleted_at IS NULL`).
+		Where("field_schedules.date = ?", date).
+		Where(
+			"field_schedules.status = ? OR (field_schedules.status = ? AND field_schedules.held_until <= ?)",
+			constants.Available, constants.Held, now,
+		)
+	if startTime != "" {
+		query = query.Where(`"Time".start_time >= ?`, startTime)
+	}
+
+	if endTime != "" {
+		query = query.Where(`"Time".end_time <= ?`, endTime)
+	}
+
+	if name != "" {
+		query = query.Where(`"Field".name ILIKE ? OR "Field".code ILIKE ?`, "%"+name+"%", "%"+name+"%")
+	}
+
+	err := query.
+		Order(`"Field".name asc`).
+		Order("field_schedules.field_id asc").
+		Order(`"Time".start_time asc`).
+		Find(&fieldSchedules).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return fieldSchedules, nil
+}
+
 func (f *FieldScheduleRepository) FindByUUID(ctx context.Context, uuid string) (*models.FieldSchedule, error) {
 	var fieldSchedule models.FieldSchedule
 	err := f.db.
 		WithContext(ctx).
 		Preload("Field").
-		Preload("Time").
+		Preload("Time", unscoped).
 		Where("uuid = ?", uuid).
 		First(&fieldSchedule).
 		Error
@@ -133,13 +246,36 @@ func (f *FieldScheduleRepository) FindByDateAndTimeID(ctx context.Context, date
 	return &fieldSchedule, nil
 }
 
-func (f *FieldScheduleRepository) Create(ctx context.Context, req []models.FieldSchedule) error {
-	err := f.db.WithContext(ctx).Create(&req).Error
+func (f *FieldScheduleRepository) FindAllByFieldIDAndDateRange(
+	ctx context.Context,
+	fieldID int,
+	startDate, endDate string,
+) ([]models.FieldSchedule, error) {
+	var fieldSchedules []models.FieldSchedule
+	err := f.db.
+		WithContext(ctx).
+		Where("field_id = ?", fieldID).
+		Where("date BETWEEN ? AND ?", startDate, endDate).
+		Find(&fieldSchedules).
+		Error
 	if err != nil {
-		return errWrap.WrapError(errConstant.ErrSQLError)
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
 	}
 
-	return nil
+	return fieldSchedules, nil
+}
+
+func (f *FieldScheduleRepository) CreateSkipExisting(ctx context.Context, tx *gorm.DB, req []models.FieldSchedule) (int64, error) {
+	// schedule yang sudah ada (field, date, time) dilewati oleh unique index
+	result := tx.
+		WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		CreateInBatches(&req, 500)
+	if result.Error != nil {
+		return 0, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return result.RowsAffected, nil
 }
 
 func (f *FieldScheduleRepository) Update(ctx context.Context, uuid string, req *models.FieldSchedule) (*models.FieldSchedule, error) {
@@ -149,27 +285,408 @@ func (f *FieldScheduleRepository) Update(ctx context.Context, uuid string, req *
 		return nil, err
 	}
 
-	// cuman bisa update tanggal field schedule aja
+	// cuman bisa update tanggal dan time field schedule aja, harga snapshot ikut diperbarui jika dikirim
 	fieldSchedule.Date = req.Date
+	fieldSchedule.TimeID = req.TimeID
+	if req.PricePerHour != nil && req.Price != nil {
+		fieldSchedule.PricePerHour = req.PricePerHour
+		fieldSchedule.Price = req.Price
+		fieldSchedule.PricingRuleName = req.PricingRuleName
+	}
 
-	err = f.db.WithContext(ctx).Save(&fieldSchedule).Error
+	// relasi tidak ikut disimpan supaya time lama yang ter-preload tidak menimpa time_id baru
+	err = f.db.WithContext(ctx).Omit(clause.Associations).Save(&fieldSchedule).Error
 	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, errWrap.WrapError(errFieldSchedule.ErrFieldScheduleIsExist)
+		}
+
 		return nil, errWrap.WrapError(errConstant.ErrSQLError)
 	}
 
 	return fieldSchedule, nil
 }
 
-func (f *FieldScheduleRepository) UpdateStatus(ctx context.Context, status constants.FieldScheduleStatus, uuid string) error {
-	fieldSchedule, err := f.FindByUUID(ctx, uuid)
+func (f *FieldScheduleRepository) FindAllByUUIDs(ctx context.Context, uuids []string) ([]models.FieldSchedule, error) {
+	var fieldSchedules []models.FieldSchedule
+	err := f.db.
+		WithContext(ctx).
+		Preload("Field").
+		Preload("Time", unscoped).
+		Where("uuid IN ?", uuids).
+		Order("date asc").
+		Order("id asc").
+		Find(&fieldSchedules).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return fieldSchedules, nil
+}
+
+func (f *FieldScheduleRepository) FindAllByUUIDsForUpdate(ctx context.Context, tx *gorm.DB, uuids []string) ([]models.FieldSchedule, error) {
+	var fieldSchedules []models.FieldSchedule
+	// urutkan berdasarkan id supaya urutan lock selalu sama dan tidak terjadi deadlock
+	err := tx.
+		WithContext(ctx).
+		Preload("Field").
+		Preload("Time", unscoped).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("uuid IN ?", uuids).
+		Order("id asc").
+		Find(&fieldSchedules).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return fieldSchedules, nil
+}
+
+// FindAllByDateRangeForUpdate mengunci schedule dalam rentang tanggal, fieldID kosong berarti semua field.
+func (f *FieldScheduleRepository) FindAllByDateRangeForUpdate(
+	ctx context.Context,
+	tx *gorm.DB,
+	fieldID *uint,
+	startDate, endDate string,
+) ([]models.FieldSchedule, error) {
+	var fieldSchedules []models.FieldSchedule
+	query := tx.
+		WithContext(ctx).
+		Preload("Field").
+		Preload("Time", unscoped).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("date BETWEEN ? AND ?", startDate, endDate)
+	if fieldID != nil {
+		query = query.Where("field_id = ?", *fieldID)
+	}
+
+	err := query.Order("id asc").Find(&fieldSchedules).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return fieldSchedules, nil
+}
+
+func (f *FieldScheduleRepository) FindAllByTimeIDForUpdate(ctx context.Context, tx *gorm.DB, timeID uint) ([]models.FieldSchedule, error) {
+	var fieldSchedules []models.FieldSchedule
+	err := tx.
+		WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("Field").
+		Preload("Time", unscoped).
+		Where("time_id = ?", timeID).
+		Order("id asc").
+		Find(&fieldSchedules).
+		Error
 	if err != nil {
-		return err
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
 	}
 
-	// update status dari field schedule
-	fieldSchedule.Status = status
+	return fieldSchedules, nil
+}
 
-	err = f.db.WithContext(ctx).Save(&fieldSchedule).Error
+func (f *FieldScheduleRepository) FindAllByFieldIDFromDateForUpdate(
+	ctx context.Context,
+	tx *gorm.DB,
+	fieldID uint,
+	startDate string,
+) ([]models.FieldSchedule, error) {
+	var fieldSchedules []models.FieldSchedule
+	err := tx.
+		WithContext(ctx).
+		Preload("Field", unscoped).
+		Preload("Time", unscoped).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("field_id = ? AND date >= ?", fieldID, startDate).
+		Order("id asc").
+		Find(&fieldSchedules).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return fieldSchedules, nil
+}
+
+func (f *FieldScheduleRepository) FindAllByFieldIDsAndDatesForUpdate(
+	ctx context.Context,
+	tx *gorm.DB,
+	fieldIDs []uint,
+	dates []string,
+) ([]models.FieldSchedule, error) {
+	var fieldSchedules []models.FieldSchedule
+	err := tx.
+		WithContext(ctx).
+		Preload("Field", unscoped).
+		Preload("Time", unscoped).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("field_id IN ?", fieldIDs).
+		Where("date IN ?", dates).
+		Order("id asc").
+		Find(&fieldSchedules).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return fieldSchedules, nil
+}
+
+func (f *FieldScheduleRepository) UpdateStatus(ctx context.Context, tx *gorm.DB, status constants.FieldScheduleStatus, ids []uint) error {
+	err := tx.
+		WithContext(ctx).
+		Model(&models.FieldSchedule{}).
+		Where("id IN ?", ids).
+		Updates(map[string]interface{}{
+			"status":     status,
+			"held_until": nil,
+			"held_by":    nil,
+			"booked_by":  nil,
+		}).
+		Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+// Book mengubah schedule menjadi booked dan mencatat user yang memesan.
+func (f *FieldScheduleRepository) Book(ctx context.Context, tx *gorm.DB, ids []uint, bookedBy *uuid.UUID) error {
+	err := tx.
+		WithContext(ctx).
+		Model(&models.FieldSchedule{}).
+		Where("id IN ?", ids).
+		Updates(map[string]interface{}{
+			"status":     constants.Booked,
+			"held_until": nil,
+			"held_by":    nil,
+			"booked_by":  bookedBy,
+		}).
+		Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+// CountBookedByUserID menghitung schedule yang sudah di-booking user di luar schedule excludeIDs.
+func (f *FieldScheduleRepository) CountBookedByUserID(
+	ctx context.Context,
+	tx *gorm.DB,
+	userID uuid.UUID,
+	excludeIDs []uint,
+) (int64, error) {
+	var total int64
+	query := tx.
+		WithContext(ctx).
+		Model(&models.FieldSchedule{}).
+		Where("status = ? AND booked_by = ?", constants.Booked, userID)
+	if len(excludeIDs) > 0 {
+		query = query.Where("id NOT IN ?", excludeIDs)
+	}
+
+	err := query.Count(&total).Error
+	if err != nil {
+		return 0, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return total, nil
+}
+
+func (f *FieldScheduleRepository) UpdateTimeID(ctx context.Context, tx *gorm.DB, timeID uint, ids []uint) error {
+	err := tx.
+		WithContext(ctx).
+		Model(&models.FieldSchedule{}).
+		Where("id IN ?", ids).
+		Update("time_id", timeID).
+		Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+// UpdatePrices menyimpan harga snapshot dan nama pricing rule beberapa schedule sekaligus dalam satu query.
+func (f *FieldScheduleRepository) UpdatePrices(ctx context.Context, tx *gorm.DB, fieldSchedules []models.FieldSchedule) error {
+	if len(fieldSchedules) == 0 {
+		return nil
+	}
+
+	values := make([]string, 0, len(fieldSchedules))
+	args := make([]interface{}, 0, len(fieldSchedules)*4)
+	for _, fieldSchedule := range fieldSchedules {
+		values = append(values, "(?::bigint, ?::int, ?::numeric, ?::varchar)")
+		args = append(args, fieldSchedule.ID, fieldSchedule.PricePerHour, fieldSchedule.Price, fieldSchedule.PricingRuleName)
+	}
+
+	err := tx.
+		WithContext(ctx).
+		Exec(
+			"UPDATE field_schedules SET price_per_hour = v.price_per_hour, price = v.price, "+
+				"pricing_rule_name = v.pricing_rule_name, updated_at = now() "+
+				"FROM (VALUES "+strings.Join(values, ", ")+") AS v(id, price_per_hour, price, pricing_rule_name) "+
+				"WHERE field_schedules.id = v.id",
+			args...,
+		).
+		Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+// Hold menahan schedule sampai heldUntil, heldBy diisi jika hold hanya boleh dipakai user tertentu.
+func (f *FieldScheduleRepository) Hold(ctx context.Context, tx *gorm.DB, ids []uint, heldUntil time.Time, heldBy *uuid.UUID) error {
+	err := tx.
+		WithContext(ctx).
+		Model(&models.FieldSchedule{}).
+		Where("id IN ?", ids).
+		Updates(map[string]interface{}{
+			"status":     constants.Held,
+			"held_until": heldUntil,
+			"held_by":    heldBy,
+		}).
+		Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+// FindAllFieldIDsWithExpiredHold mengambil field yang punya hold kedaluwarsa tanpa mengunci schedule.
+func (f *FieldScheduleRepository) FindAllFieldIDsWithExpiredHold(ctx context.Context, now time.Time) ([]uint, error) {
+	var fieldIDs []uint
+	err := f.db.
+		WithContext(ctx).
+		Model(&models.FieldSchedule{}).
+		Distinct("field_id").
+		Where("status = ?", constants.Held).
+		Where("held_until <= ?", now).
+		Order("field_id asc").
+		Pluck("field_id", &fieldIDs).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return fieldIDs, nil
+}
+
+func (f *FieldScheduleRepository) FindAllExpiredHoldForUpdate(
+	ctx context.Context,
+	tx *gorm.DB,
+	now time.Time,
+	fieldIDs []uint,
+) ([]models.FieldSchedule, error) {
+	var fieldSchedules []models.FieldSchedule
+	err := tx.
+		WithContext(ctx).
+		Preload("Field").
+		Preload("Time", unscoped).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ?", constants.Held).
+		Where("held_until <= ?", now).
+		Where("field_id IN ?", fieldIDs).
+		Order("id asc").
+		Find(&fieldSchedules).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return fieldSchedules, nil
+}
+
+// FindAllByStandingReservationIDForUpdate mengunci schedule milik standing reservation mulai dari tanggal fromDate.
+func (f *FieldScheduleRepository) FindAllByStandingReservationIDForUpdate(
+	ctx context.Context,
+	tx *gorm.DB,
+	standingReservationID uint,
+	fromDate string,
+) ([]models.FieldSchedule, error) {
+	var fieldSchedules []models.FieldSchedule
+	err := tx.
+		WithContext(ctx).
+		Preload("Field").
+		Preload("Time", unscoped).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("standing_reservation_id = ?", standingReservationID).
+		Where("date >= ?", fromDate).
+		Order("id asc").
+		Find(&fieldSchedules).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return fieldSchedules, nil
+}
+
+func (f *FieldScheduleRepository) UpdateStandingReservationID(
+	ctx context.Context,
+	tx *gorm.DB,
+	standingReservationID *uint,
+	ids []uint,
+) error {
+	err := tx.
+		WithContext(ctx).
+		Model(&models.FieldSchedule{}).
+		Where("id IN ?", ids).
+		Update("standing_reservation_id", standingReservationID).
+		Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+func (f *FieldScheduleRepository) CreateWithTx(ctx context.Context, tx *gorm.DB, req []models.FieldSchedule) error {
+	err := tx.WithContext(ctx).Create(&req).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return errWrap.WrapError(errFieldSchedule.ErrFieldScheduleIsExist)
+		}
+
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+func (f *FieldScheduleRepository) FindAllByEventIDForUpdate(ctx context.Context, tx *gorm.DB, eventID uint) ([]models.FieldSchedule, error) {
+	var fieldSchedules []models.FieldSchedule
+	err := tx.
+		WithContext(ctx).
+		Preload("Field").
+		Preload("Time", unscoped).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("event_id = ?", eventID).
+		Order("id asc").
+		Find(&fieldSchedules).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return fieldSchedules, nil
+}
+
+func (f *FieldScheduleRepository) UpdateEventID(ctx context.Context, tx *gorm.DB, eventID *uint, ids []uint) error {
+	err := tx.
+		WithContext(ctx).
+		Model(&models.FieldSchedule{}).
+		Where("id IN ?", ids).
+		Update("event_id", eventID).
+		Error
 	if err != nil {
 		return errWrap.WrapError(errConstant.ErrSQLError)
 	}
@@ -185,3 +702,53 @@ func (f *FieldScheduleRepository) Delete(ctx context.Context, uuid string) error
 
 	return nil
 }
+
+func (f *FieldScheduleRepository) DeleteByIDs(ctx context.Context, tx *gorm.DB, ids []uint) error {
+	err := tx.WithContext(ctx).Where("id IN ?", ids).Delete(&models.FieldSchedule{}).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+// TryAdvisoryLock mengambil advisory lock postgres yang otomatis dilepas ketika transaksi selesai.
+func (f *FieldScheduleRepository) TryAdvisoryLock(ctx context.Context, tx *gorm.DB, key int64) (bool, error) {
+	var locked bool
+	err := tx.WithContext(ctx).Raw("SELECT pg_try_advisory_xact_lock(?)", key).Scan(&locked).Error
+	if err != nil {
+		return false, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return locked, nil
+}
+
+// AdvisoryLock menunggu advisory lock postgres untuk pasangan key dan id,
+// lock otomatis dilepas ketika transaksi selesai.
+func (f *FieldScheduleRepository) AdvisoryLock(ctx context.Context, tx *gorm.DB, key int, id uint) error {
+	err := tx.WithContext(ctx).Exec("SELECT pg_advisory_xact_lock(?, ?)", key, id).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+// FindLastDateByFieldID mengambil tanggal schedule terakhir yang pernah dibuat untuk field,
+// schedule yang sudah dihapus ikut dihitung.
+func (f *FieldScheduleRepository) FindLastDateByFieldID(ctx context.Context, fieldID uint) (*time.Time, error) {
+	var lastDate *time.Time
+	err := f.db.
+		WithContext(ctx).
+		Unscoped().
+		Model(&models.FieldSchedule{}).
+		Select("max(date)").
+		Where("field_id = ?", fieldID).
+		Scan(&lastDate).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return lastDate, nil
+}
@@ -0,0 +1,32 @@
+package repositories
+
+import (
+	"context"
+	errWrap "field-service/common/error"
+	errConstant "field-service/constants/error"
+	"field-service/domain/models"
+	"gorm.io/gorm"
+)
+
+type FieldScheduleHistoryRepository struct {
+	db *gorm.DB
+}
+
+type IFieldScheduleHistoryRepository interface {
+	Create(context.Context, *gorm.DB, []models.FieldScheduleHistory) error
+}
+
+func NewFieldScheduleHistoryRepository(db *gorm.DB) IFieldScheduleHistoryRepository {
+	return &FieldScheduleHistoryRepository{
+		db: db,
+	}
+}
+
+func (f *FieldScheduleHistoryRepository) Create(ctx context.Context, tx *gorm.DB, req []models.FieldScheduleHistory) error {
+	err := tx.WithContext(ctx).Create(&req).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
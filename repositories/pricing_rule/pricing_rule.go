@@ -0,0 +1,141 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	errWrap "field-service/common/error"
+	errConstant "field-service/constants/error"
+	errPricingRule "field-service/constants/error/pricingRule"
+	"field-service/domain/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PricingRuleRepository struct {
+	db *gorm.DB
+}
+
+type IPricingRuleRepository interface {
+	FindAll(context.Context, *uint) ([]models.PricingRule, error)
+	FindAllByFieldIDs(context.Context, []uint) ([]models.PricingRule, error)
+	FindByUUID(context.Context, string) (*models.PricingRule, error)
+	Create(context.Context, *models.PricingRule) (*models.PricingRule, error)
+	Update(context.Context, string, *models.PricingRule) (*models.PricingRule, error)
+	Delete(context.Context, string) error
+}
+
+func NewPricingRuleRepository(db *gorm.DB) IPricingRuleRepository {
+	return &PricingRuleRepository{db: db}
+}
+
+func (p *PricingRuleRepository) FindAll(ctx context.Context, fieldID *uint) ([]models.PricingRule, error) {
+	var pricingRules []models.PricingRule
+	query := p.db.
+		WithContext(ctx).
+		Preload("Field")
+	if fieldID != nil {
+		query = query.Where("field_id = ?", *fieldID)
+	}
+
+	err := query.Order("priority desc").Order("id asc").Find(&pricingRules).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return pricingRules, nil
+}
+
+// FindAllByFieldIDs mengambil pricing rule beberapa field sekaligus, diurutkan dari prioritas tertinggi.
+func (p *PricingRuleRepository) FindAllByFieldIDs(ctx context.Context, fieldIDs []uint) ([]models.PricingRule, error) {
+	var pricingRules []models.PricingRule
+	if len(fieldIDs) == 0 {
+		return pricingRules, nil
+	}
+
+	err := p.db.
+		WithContext(ctx).
+		Where("field_id IN ?", fieldIDs).
+		Order("priority desc").
+		Order("id asc").
+		Find(&pricingRules).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return pricingRules, nil
+}
+
+func (p *PricingRuleRepository) FindByUUID(ctx context.Context, uuid string) (*models.PricingRule, error) {
+	var pricingRule models.PricingRule
+	err := p.db.
+		WithContext(ctx).
+		Preload("Field").
+		Where("uuid = ?", uuid).
+		First(&pricingRule).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapError(errPricingRule.ErrPricingRuleNotFound)
+		}
+
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &pricingRule, nil
+}
+
+func (p *PricingRuleRepository) Create(ctx context.Context, req *models.PricingRule) (*models.PricingRule, error) {
+	pricingRule := models.PricingRule{
+		UUID:         uuid.New(),
+		FieldID:      req.FieldID,
+		Name:         req.Name,
+		Weekdays:     req.Weekdays,
+		StartTime:    req.StartTime,
+		EndTime:      req.EndTime,
+		StartDate:    req.StartDate,
+		EndDate:      req.EndDate,
+		PricePerHour: req.PricePerHour,
+		Priority:     req.Priority,
+	}
+
+	err := p.db.WithContext(ctx).Omit("Field").Create(&pricingRule).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &pricingRule, nil
+}
+
+func (p *PricingRuleRepository) Update(ctx context.Context, uuid string, req *models.PricingRule) (*models.PricingRule, error) {
+	pricingRule, err := p.FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	pricingRule.FieldID = req.FieldID
+	pricingRule.Name = req.Name
+	pricingRule.Weekdays = req.Weekdays
+	pricingRule.StartTime = req.StartTime
+	pricingRule.EndTime = req.EndTime
+	pricingRule.StartDate = req.StartDate
+	pricingRule.EndDate = req.EndDate
+	pricingRule.PricePerHour = req.PricePerHour
+	pricingRule.Priority = req.Priority
+
+	err = p.db.WithContext(ctx).Omit("Field").Save(pricingRule).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return pricingRule, nil
+}
+
+func (p *PricingRuleRepository) Delete(ctx context.Context, uuid string) error {
+	err := p.db.WithContext(ctx).Where("uuid = ?", uuid).Delete(&models.PricingRule{}).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
@@ -0,0 +1,154 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	errWrap "field-service/common/error"
+	errConstant "field-service/constants/error"
+	errPromo "field-service/constants/error/promo"
+	"field-service/domain/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PromoRepository struct {
+	db *gorm.DB
+}
+
+type IPromoRepository interface {
+	FindAll(context.Context) ([]models.Promo, error)
+	FindByUUID(context.Context, string) (*models.Promo, error)
+	FindByCode(context.Context, *gorm.DB, string, bool) (*models.Promo, error)
+	Create(context.Context, *models.Promo) (*models.Promo, error)
+	Update(context.Context, string, *models.Promo) (*models.Promo, error)
+	Delete(context.Context, string) error
+}
+
+func NewPromoRepository(db *gorm.DB) IPromoRepository {
+	return &PromoRepository{db: db}
+}
+
+func (p *PromoRepository) FindAll(ctx context.Context) ([]models.Promo, error) {
+	var promos []models.Promo
+	err := p.db.
+		WithContext(ctx).
+		Preload("Fields").
+		Order("created_at desc").
+		Find(&promos).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return promos, nil
+}
+
+func (p *PromoRepository) FindByUUID(ctx context.Context, uuid string) (*models.Promo, error) {
+	var promo models.Promo
+	err := p.db.
+		WithContext(ctx).
+		Preload("Fields").
+		Where("uuid = ?", uuid).
+		First(&promo).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapError(errPromo.ErrPromoNotFound)
+		}
+
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &promo, nil
+}
+
+// FindByCode mencari promo berdasarkan kode, forUpdate mengunci baris promo sampai transaksi selesai
+// supaya batas pemakaian tidak terlewati oleh redeem yang berjalan bersamaan.
+func (p *PromoRepository) FindByCode(ctx context.Context, tx *gorm.DB, code string, forUpdate bool) (*models.Promo, error) {
+	var promo models.Promo
+	query := tx.WithContext(ctx)
+	if forUpdate {
+		query = query.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+
+	err := query.
+		Preload("Fields").
+		Where("code = ?", code).
+		First(&promo).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapError(errPromo.ErrPromoNotFound)
+		}
+
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &promo, nil
+}
+
+func (p *PromoRepository) Create(ctx context.Context, req *models.Promo) (*models.Promo, error) {
+	promo := *req
+	promo.UUID = uuid.New()
+
+	err := p.db.WithContext(ctx).Omit("Fields.*").Create(&promo).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, errWrap.WrapError(errPromo.ErrPromoCodeIsExist)
+		}
+
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &promo, nil
+}
+
+func (p *PromoRepository) Update(ctx context.Context, uuid string, req *models.Promo) (*models.Promo, error) {
+	promo, err := p.FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	promo.Code = req.Code
+	promo.Name = req.Name
+	promo.DiscountType = req.DiscountType
+	promo.DiscountValue = req.DiscountValue
+	promo.MaxDiscount = req.MaxDiscount
+	promo.StartDate = req.StartDate
+	promo.EndDate = req.EndDate
+	promo.Weekdays = req.Weekdays
+	promo.StartTime = req.StartTime
+	promo.EndTime = req.EndTime
+	promo.UsageLimit = req.UsageLimit
+	promo.PerUserLimit = req.PerUserLimit
+	promo.FirstBooking = req.FirstBooking
+
+	err = p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Omit("Fields").Save(promo).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(promo).Omit("Fields.*").Association("Fields").Replace(req.Fields)
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, errWrap.WrapError(errPromo.ErrPromoCodeIsExist)
+		}
+
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	promo.Fields = req.Fields
+	return promo, nil
+}
+
+func (p *PromoRepository) Delete(ctx context.Context, uuid string) error {
+	err := p.db.WithContext(ctx).Where("uuid = ?", uuid).Delete(&models.Promo{}).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	errWrap "field-service/common/error"
+	errConstant "field-service/constants/error"
+	errPromo "field-service/constants/error/promo"
+	"field-service/domain/models"
+	"gorm.io/gorm"
+)
+
+type PromoUsageRepository struct {
+	db *gorm.DB
+}
+
+type IPromoUsageRepository interface {
+	CountByPromoID(context.Context, *gorm.DB, uint) (int64, error)
+	CountByPromoIDAndUserID(context.Context, *gorm.DB, uint, string) (int64, error)
+	Create(context.Context, *gorm.DB, *models.PromoUsage, []uint) error
+}
+
+func NewPromoUsageRepository(db *gorm.DB) IPromoUsageRepository {
+	return &PromoUsageRepository{db: db}
+}
+
+func (p *PromoUsageRepository) CountByPromoID(ctx context.Context, tx *gorm.DB, promoID uint) (int64, error) {
+	var total int64
+	err := tx.
+		WithContext(ctx).
+		Model(&models.PromoUsage{}).
+		Where("promo_id = ?", promoID).
+		Count(&total).
+		Error
+	if err != nil {
+		return 0, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return total, nil
+}
+
+func (p *PromoUsageRepository) CountByPromoIDAndUserID(ctx context.Context, tx *gorm.DB, promoID uint, userID string) (int64, error) {
+	var total int64
+	err := tx.
+		WithContext(ctx).
+		Model(&models.PromoUsage{}).
+		Where("promo_id = ?", promoID).
+		Where("user_id = ?", userID).
+		Count(&total).
+		Error
+	if err != nil {
+		return 0, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return total, nil
+}
+
+// Create menyimpan pemakaian promo beserta schedule-nya, satu schedule hanya bisa dipakai sekali untuk satu reference.
+func (p *PromoUsageRepository) Create(ctx context.Context, tx *gorm.DB, req *models.PromoUsage, fieldScheduleIDs []uint) error {
+	err := tx.WithContext(ctx).Omit("Promo").Create(req).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return errWrap.WrapError(errPromo.ErrPromoAlreadyRedeemed)
+		}
+
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	promoUsageSchedules := make([]models.PromoUsageSchedule, 0, len(fieldScheduleIDs))
+	for _, fieldScheduleID := range fieldScheduleIDs {
+		promoUsageSchedules = append(promoUsageSchedules, models.PromoUsageSchedule{
+			PromoUsageID:    req.ID,
+			FieldScheduleID: fieldScheduleID,
+			Reference:       req.Reference,
+		})
+	}
+
+	err = tx.WithContext(ctx).Omit("PromoUsage", "FieldSchedule").Create(&promoUsageSchedules).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return errWrap.WrapError(errPromo.ErrPromoAlreadyRedeemed)
+		}
+
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
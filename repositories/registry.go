@@ -1,9 +1,19 @@
 package repositories
 
 import (
+	blackoutRepo "field-service/repositories/blackout"
+	eventRepo "field-service/repositories/event"
 	fieldRepo "field-service/repositories/field"
 	fieldScheduleRepo "field-service/repositories/field_schedule"
+	fieldScheduleHistoryRepo "field-service/repositories/field_schedule_history"
+	pricingRuleRepo "field-service/repositories/pricing_rule"
+	promoRepo "field-service/repositories/promo"
+	promoUsageRepo "field-service/repositories/promo_usage"
+	scheduleRuleRepo "field-service/repositories/schedule_rule"
+	standingReservationRepo "field-service/repositories/standing_reservation"
 	timeRepo "field-service/repositories/time"
+	timeSetRepo "field-service/repositories/time_set"
+	waitlistRepo "field-service/repositories/waitlist"
 	"gorm.io/gorm"
 )
 
@@ -14,7 +24,18 @@ type Registry struct {
 type IRepositoryRegistry interface {
 	GetField() fieldRepo.IFieldRepository
 	GetFieldSchedule() fieldScheduleRepo.IFieldScheduleRepository
+	GetFieldScheduleHistory() fieldScheduleHistoryRepo.IFieldScheduleHistoryRepository
 	GetTime() timeRepo.ITimeRepository
+	GetScheduleRule() scheduleRuleRepo.IScheduleRuleRepository
+	GetBlackout() blackoutRepo.IBlackoutRepository
+	GetTimeSet() timeSetRepo.ITimeSetRepository
+	GetPricingRule() pricingRuleRepo.IPricingRuleRepository
+	GetPromo() promoRepo.IPromoRepository
+	GetPromoUsage() promoUsageRepo.IPromoUsageRepository
+	GetWaitlist() waitlistRepo.IWaitlistRepository
+	GetStandingReservation() standingReservationRepo.IStandingReservationRepository
+	GetEvent() eventRepo.IEventRepository
+	GetTx() *gorm.DB
 }
 
 func NewRepositoryRegistry(db *gorm.DB) *Registry {
@@ -29,6 +50,50 @@ func (r *Registry) GetFieldSchedule() fieldScheduleRepo.IFieldScheduleRepository
 	return fieldScheduleRepo.NewFieldScheduleRepository(r.db)
 }
 
+func (r *Registry) GetFieldScheduleHistory() fieldScheduleHistoryRepo.IFieldScheduleHistoryRepository {
+	return fieldScheduleHistoryRepo.NewFieldScheduleHistoryRepository(r.db)
+}
+
 func (r *Registry) GetTime() timeRepo.ITimeRepository {
 	return timeRepo.NewTimeRepository(r.db)
 }
+
+func (r *Registry) GetScheduleRule() scheduleRuleRepo.IScheduleRuleRepository {
+	return scheduleRuleRepo.NewScheduleRuleRepository(r.db)
+}
+
+func (r *Registry) GetBlackout() blackoutRepo.IBlackoutRepository {
+	return blackoutRepo.NewBlackoutRepository(r.db)
+}
+
+func (r *Registry) GetTimeSet() timeSetRepo.ITimeSetRepository {
+	return timeSetRepo.NewTimeSetRepository(r.db)
+}
+
+func (r *Registry) GetPricingRule() pricingRuleRepo.IPricingRuleRepository {
+	return pricingRuleRepo.NewPricingRuleRepository(r.db)
+}
+
+func (r *Registry) GetPromo() promoRepo.IPromoRepository {
+	return promoRepo.NewPromoRepository(r.db)
+}
+
+func (r *Registry) GetPromoUsage() promoUsageRepo.IPromoUsageRepository {
+	return promoUsageRepo.NewPromoUsageRepository(r.db)
+}
+
+func (r *Registry) GetWaitlist() waitlistRepo.IWaitlistRepository {
+	return waitlistRepo.NewWaitlistRepository(r.db)
+}
+
+func (r *Registry) GetStandingReservation() standingReservationRepo.IStandingReservationRepository {
+	return standingReservationRepo.NewStandingReservationRepository(r.db)
+}
+
+func (r *Registry) GetEvent() eventRepo.IEventRepository {
+	return eventRepo.NewEventRepository(r.db)
+}
+
+func (r *Registry) GetTx() *gorm.DB {
+	return r.db
+}
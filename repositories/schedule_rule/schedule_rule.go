@@ -0,0 +1,142 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	errWrap "field-service/common/error"
+	errConstant "field-service/constants/error"
+	errScheduleRule "field-service/constants/error/scheduleRule"
+	"field-service/domain/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ScheduleRuleRepository struct {
+	db *gorm.DB
+}
+
+type IScheduleRuleRepository interface {
+	FindAll(context.Context, *uint) ([]models.ScheduleRule, error)
+	FindAllByFieldIDAndDateRange(context.Context, int, string, string) ([]models.ScheduleRule, error)
+	FindByUUID(context.Context, string) (*models.ScheduleRule, error)
+	Create(context.Context, *models.ScheduleRule) (*models.ScheduleRule, error)
+	Update(context.Context, string, *models.ScheduleRule) (*models.ScheduleRule, error)
+	Delete(context.Context, string) error
+}
+
+func NewScheduleRuleRepository(db *gorm.DB) IScheduleRuleRepository {
+	return &ScheduleRuleRepository{db: db}
+}
+
+func (s *ScheduleRuleRepository) FindAll(ctx context.Context, fieldID *uint) ([]models.ScheduleRule, error) {
+	var scheduleRules []models.ScheduleRule
+	query := s.db.
+		WithContext(ctx).
+		Preload("Field").
+		Preload("Times")
+	if fieldID != nil {
+		query = query.Where("field_id = ?", *fieldID)
+	}
+
+	err := query.Order("created_at desc").Find(&scheduleRules).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return scheduleRules, nil
+}
+
+func (s *ScheduleRuleRepository) FindAllByFieldIDAndDateRange(
+	ctx context.Context,
+	fieldID int,
+	startDate, endDate string,
+) ([]models.ScheduleRule, error) {
+	var scheduleRules []models.ScheduleRule
+	err := s.db.
+		WithContext(ctx).
+		Preload("Times").
+		Where("field_id = ?", fieldID).
+		Where("start_date <= ?", endDate).
+		Where("end_date >= ?", startDate).
+		Find(&scheduleRules).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return scheduleRules, nil
+}
+
+func (s *ScheduleRuleRepository) FindByUUID(ctx context.Context, uuid string) (*models.ScheduleRule, error) {
+	var scheduleRule models.ScheduleRule
+	err := s.db.
+		WithContext(ctx).
+		Preload("Field").
+		Preload("Times").
+		Where("uuid = ?", uuid).
+		First(&scheduleRule).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapError(errScheduleRule.ErrScheduleRuleNotFound)
+		}
+
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &scheduleRule, nil
+}
+
+func (s *ScheduleRuleRepository) Create(ctx context.Context, req *models.ScheduleRule) (*models.ScheduleRule, error) {
+	scheduleRule := models.ScheduleRule{
+		UUID:      uuid.New(),
+		FieldID:   req.FieldID,
+		Weekdays:  req.Weekdays,
+		StartDate: req.StartDate,
+		EndDate:   req.EndDate,
+		Times:     req.Times,
+	}
+
+	err := s.db.WithContext(ctx).Omit("Times.*").Create(&scheduleRule).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &scheduleRule, nil
+}
+
+func (s *ScheduleRuleRepository) Update(ctx context.Context, uuid string, req *models.ScheduleRule) (*models.ScheduleRule, error) {
+	scheduleRule, err := s.FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduleRule.FieldID = req.FieldID
+	scheduleRule.Weekdays = req.Weekdays
+	scheduleRule.StartDate = req.StartDate
+	scheduleRule.EndDate = req.EndDate
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Omit("Field", "Times").Save(scheduleRule).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(scheduleRule).Omit("Times.*").Association("Times").Replace(req.Times)
+	})
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	scheduleRule.Times = req.Times
+	return scheduleRule, nil
+}
+
+func (s *ScheduleRuleRepository) Delete(ctx context.Context, uuid string) error {
+	err := s.db.WithContext(ctx).Where("uuid = ?", uuid).Delete(&models.ScheduleRule{}).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
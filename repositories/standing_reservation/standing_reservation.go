@@ -0,0 +1,158 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	errWrap "field-service/common/error"
+	errConstant "field-service/constants/error"
+	errStandingReservation "field-service/constants/error/standingReservation"
+	"field-service/domain/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+type StandingReservationRepository struct {
+	db *gorm.DB
+}
+
+type IStandingReservationRepository interface {
+	FindAll(context.Context, *uuid.UUID) ([]models.StandingReservation, error)
+	FindByUUID(context.Context, string) (*models.StandingReservation, error)
+	FindAllActiveByFieldIDAndDateRange(context.Context, uint, string, string) ([]models.StandingReservation, error)
+	CountOverlap(context.Context, *gorm.DB, *models.StandingReservation) (int64, error)
+	Create(context.Context, *gorm.DB, *models.StandingReservation) (*models.StandingReservation, error)
+	Cancel(context.Context, *gorm.DB, uint, time.Time) error
+}
+
+func NewStandingReservationRepository(db *gorm.DB) IStandingReservationRepository {
+	return &StandingReservationRepository{db: db}
+}
+
+// FindAll mengambil semua standing reservation, ownerID diisi untuk membatasi milik satu user.
+func (s *StandingReservationRepository) FindAll(ctx context.Context, ownerID *uuid.UUID) ([]models.StandingReservation, error) {
+	var standingReservations []models.StandingReservation
+	query := s.db.
+		WithContext(ctx).
+		Preload("Field").
+		Preload("Time", unscoped)
+	if ownerID != nil {
+		query = query.Where("owner_id = ?", *ownerID)
+	}
+
+	err := query.Order("created_at desc").Find(&standingReservations).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return standingReservations, nil
+}
+
+func (s *StandingReservationRepository) FindByUUID(ctx context.Context, uuid string) (*models.StandingReservation, error) {
+	var standingReservation models.StandingReservation
+	err := s.db.
+		WithContext(ctx).
+		Preload("Field").
+		Preload("Time", unscoped).
+		Where("uuid = ?", uuid).
+		First(&standingReservation).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapError(errStandingReservation.ErrStandingReservationNotFound)
+		}
+
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &standingReservation, nil
+}
+
+// FindAllActiveByFieldIDAndDateRange mengambil standing reservation yang belum dibatalkan
+// dan beririsan dengan rentang tanggal, dipakai saat generate schedule.
+func (s *StandingReservationRepository) FindAllActiveByFieldIDAndDateRange(
+	ctx context.Context,
+	fieldID uint,
+	startDate, endDate string,
+) ([]models.StandingReservation, error) {
+	var standingReservations []models.StandingReservation
+	err := s.db.
+		WithContext(ctx).
+		Where("field_id = ?", fieldID).
+		Where("cancelled_at IS NULL").
+		Where("start_date <= ? AND end_date >= ?", endDate, startDate).
+		Order("id asc").
+		Find(&standingReservations).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return standingReservations, nil
+}
+
+func (s *StandingReservationRepository) CountOverlap(
+	ctx context.Context,
+	tx *gorm.DB,
+	req *models.StandingReservation,
+) (int64, error) {
+	var total int64
+	err := tx.
+		WithContext(ctx).
+		Model(&models.StandingReservation{}).
+		Where("field_id = ?", req.FieldID).
+		Where("time_id = ?", req.TimeID).
+		Where("weekday = ?", req.Weekday).
+		Where("cancelled_at IS NULL").
+		Where("start_date <= ? AND end_date >= ?", req.EndDate, req.StartDate).
+		Count(&total).
+		Error
+	if err != nil {
+		return 0, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return total, nil
+}
+
+func (s *StandingReservationRepository) Create(
+	ctx context.Context,
+	tx *gorm.DB,
+	req *models.StandingReservation,
+) (*models.StandingReservation, error) {
+	standingReservation := models.StandingReservation{
+		UUID:      uuid.New(),
+		FieldID:   req.FieldID,
+		TimeID:    req.TimeID,
+		Weekday:   req.Weekday,
+		StartDate: req.StartDate,
+		EndDate:   req.EndDate,
+		Name:      req.Name,
+		OwnerID:   req.OwnerID,
+	}
+
+	err := tx.WithContext(ctx).Create(&standingReservation).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &standingReservation, nil
+}
+
+func (s *StandingReservationRepository) Cancel(ctx context.Context, tx *gorm.DB, id uint, cancelledAt time.Time) error {
+	err := tx.
+		WithContext(ctx).
+		Model(&models.StandingReservation{}).
+		Where("id = ?", id).
+		Update("cancelled_at", cancelledAt).
+		Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+// unscoped dipakai saat preload time supaya reservation lama yang time-nya sudah dihapus tetap tampil
+func unscoped(db *gorm.DB) *gorm.DB {
+	return db.Unscoped()
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	"gorm.io/gorm"
+	timePkg "time"
 )
 
 type TimeRepository struct {
@@ -17,18 +18,50 @@ type TimeRepository struct {
 
 type ITimeRepository interface {
 	FindAll(context.Context) ([]models.Time, error)
+	FindAllByTimeSetID(context.Context, *uint) ([]models.Time, error)
 	FindByUUID(context.Context, string) (*models.Time, error)
 	FindByID(context.Context, int) (*models.Time, error)
+	FindOverlap(context.Context, *uint, string, string, uint) (*models.Time, error)
 	Create(context.Context, *models.Time) (*models.Time, error)
+	CreateArchive(context.Context, *gorm.DB, *models.Time) (*models.Time, error)
+	Update(context.Context, *gorm.DB, string, *models.Time) (*models.Time, error)
+	Delete(context.Context, *gorm.DB, string) error
+	NormalizeAll(context.Context) (int64, error)
 }
 
 func NewTimeRepository(db *gorm.DB) ITimeRepository {
 	return &TimeRepository{db: db}
 }
 
+// byTimeSet membatasi query ke satu time set, time set kosong berarti set default.
+func byTimeSet(timeSetID *uint) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if timeSetID == nil {
+			return db.Where("time_set_id IS NULL")
+		}
+
+		return db.Where("time_set_id = ?", *timeSetID)
+	}
+}
+
 func (t *TimeRepository) FindAll(ctx context.Context) ([]models.Time, error) {
 	var times []models.Time
-	if err := t.db.WithContext(ctx).Find(&times).Error; err != nil {
+	if err := t.db.WithContext(ctx).Preload("TimeSet").Find(&times).Error; err != nil {
+		return nil, errorWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return times, nil
+}
+
+func (t *TimeRepository) FindAllByTimeSetID(ctx context.Context, timeSetID *uint) ([]models.Time, error) {
+	var times []models.Time
+	err := t.db.
+		WithContext(ctx).
+		Scopes(byTimeSet(timeSetID)).
+		Order("start_time asc").
+		Find(&times).
+		Error
+	if err != nil {
 		return nil, errorWrap.WrapError(errConstant.ErrSQLError)
 	}
 
@@ -37,7 +70,7 @@ func (t *TimeRepository) FindAll(ctx context.Context) ([]models.Time, error) {
 
 func (t *TimeRepository) FindByUUID(ctx context.Context, uuid string) (*models.Time, error) {
 	var time models.Time
-	if err := t.db.WithContext(ctx).Where("uuid = ?", uuid).First(&time).Error; err != nil {
+	if err := t.db.WithContext(ctx).Preload("TimeSet").Where("uuid = ?", uuid).First(&time).Error; err != nil {
 
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errorWrap.WrapError(errTime.ErrTimeNotFound)
@@ -63,6 +96,32 @@ func (t *TimeRepository) FindByID(ctx context.Context, id int) (*models.Time, er
 	return &time, nil
 }
 
+func (t *TimeRepository) FindOverlap(
+	ctx context.Context,
+	timeSetID *uint,
+	startTime, endTime string,
+	excludeID uint,
+) (*models.Time, error) {
+	var time models.Time
+	err := t.db.
+		WithContext(ctx).
+		Scopes(byTimeSet(timeSetID)).
+		Where("start_time < ?", endTime).
+		Where("end_time > ?", startTime).
+		Where("id <> ?", excludeID).
+		First(&time).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, errorWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &time, nil
+}
+
 func (t *TimeRepository) Create(ctx context.Context, time *models.Time) (*models.Time, error) {
 	time.UUID = uuid.New()
 	if err := t.db.WithContext(ctx).Create(&time).Error; err != nil {
@@ -71,3 +130,56 @@ func (t *TimeRepository) Create(ctx context.Context, time *models.Time) (*models
 
 	return time, nil
 }
+
+// CreateArchive menyimpan salinan time lama dalam keadaan terhapus supaya riwayat schedule tetap utuh.
+func (t *TimeRepository) CreateArchive(ctx context.Context, tx *gorm.DB, req *models.Time) (*models.Time, error) {
+	time := models.Time{
+		UUID:      uuid.New(),
+		TimeSetID: req.TimeSetID,
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+		DeletedAt: &gorm.DeletedAt{Time: timePkg.Now(), Valid: true},
+	}
+
+	if err := tx.WithContext(ctx).Create(&time).Error; err != nil {
+		return nil, errorWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &time, nil
+}
+
+func (t *TimeRepository) Update(ctx context.Context, tx *gorm.DB, uuid string, req *models.Time) (*models.Time, error) {
+	time, err := t.FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	time.StartTime = req.StartTime
+	time.EndTime = req.EndTime
+	if err := tx.WithContext(ctx).Omit("TimeSet").Save(time).Error; err != nil {
+		return nil, errorWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return time, nil
+}
+
+func (t *TimeRepository) Delete(ctx context.Context, tx *gorm.DB, uuid string) error {
+	if err := tx.WithContext(ctx).Where("uuid = ?", uuid).Delete(&models.Time{}).Error; err != nil {
+		return errorWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+// NormalizeAll membuang pecahan detik dari time lama, termasuk time yang sudah dihapus atau diarsipkan.
+func (t *TimeRepository) NormalizeAll(ctx context.Context) (int64, error) {
+	result := t.db.
+		WithContext(ctx).
+		Exec("UPDATE times SET start_time = start_time::time(0), end_time = end_time::time(0) " +
+			"WHERE start_time <> start_time::time(0) OR end_time <> end_time::time(0)")
+	if result.Error != nil {
+		return 0, errorWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return result.RowsAffected, nil
+}
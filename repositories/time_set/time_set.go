@@ -0,0 +1,129 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	errWrap "field-service/common/error"
+	errConstant "field-service/constants/error"
+	errTimeSet "field-service/constants/error/timeSet"
+	"field-service/domain/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type TimeSetRepository struct {
+	db *gorm.DB
+}
+
+type ITimeSetRepository interface {
+	FindAll(context.Context) ([]models.TimeSet, error)
+	FindByUUID(context.Context, string) (*models.TimeSet, error)
+	IsUsed(context.Context, uint) (bool, error)
+	Create(context.Context, *models.TimeSet) (*models.TimeSet, error)
+	Update(context.Context, string, *models.TimeSet) (*models.TimeSet, error)
+	Delete(context.Context, string) error
+}
+
+func NewTimeSetRepository(db *gorm.DB) ITimeSetRepository {
+	return &TimeSetRepository{db: db}
+}
+
+func (t *TimeSetRepository) FindAll(ctx context.Context) ([]models.TimeSet, error) {
+	var timeSets []models.TimeSet
+	err := t.db.
+		WithContext(ctx).
+		Order("name asc").
+		Find(&timeSets).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return timeSets, nil
+}
+
+func (t *TimeSetRepository) FindByUUID(ctx context.Context, uuid string) (*models.TimeSet, error) {
+	var timeSet models.TimeSet
+	err := t.db.
+		WithContext(ctx).
+		Where("uuid = ?", uuid).
+		First(&timeSet).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapError(errTimeSet.ErrTimeSetNotFound)
+		}
+
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &timeSet, nil
+}
+
+// IsUsed mengecek apakah masih ada field atau time aktif yang memakai time set ini.
+func (t *TimeSetRepository) IsUsed(ctx context.Context, id uint) (bool, error) {
+	var total int64
+	err := t.db.
+		WithContext(ctx).
+		Model(&models.Field{}).
+		Where("time_set_id = ?", id).
+		Count(&total).
+		Error
+	if err != nil {
+		return false, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	if total > 0 {
+		return true, nil
+	}
+
+	err = t.db.
+		WithContext(ctx).
+		Model(&models.Time{}).
+		Where("time_set_id = ?", id).
+		Count(&total).
+		Error
+	if err != nil {
+		return false, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return total > 0, nil
+}
+
+func (t *TimeSetRepository) Create(ctx context.Context, req *models.TimeSet) (*models.TimeSet, error) {
+	timeSet := models.TimeSet{
+		UUID: uuid.New(),
+		Name: req.Name,
+	}
+
+	err := t.db.WithContext(ctx).Create(&timeSet).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &timeSet, nil
+}
+
+func (t *TimeSetRepository) Update(ctx context.Context, uuid string, req *models.TimeSet) (*models.TimeSet, error) {
+	timeSet, err := t.FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	timeSet.Name = req.Name
+	err = t.db.WithContext(ctx).Save(timeSet).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return timeSet, nil
+}
+
+func (t *TimeSetRepository) Delete(ctx context.Context, uuid string) error {
+	err := t.db.WithContext(ctx).Where("uuid = ?", uuid).Delete(&models.TimeSet{}).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
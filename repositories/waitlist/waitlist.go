@@ -0,0 +1,212 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	errWrap "field-service/common/error"
+	"field-service/constants"
+	errConstant "field-service/constants/error"
+	errWaitlist "field-service/constants/error/waitlist"
+	"field-service/domain/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type WaitlistRepository struct {
+	db *gorm.DB
+}
+
+type IWaitlistRepository interface {
+	FindAllByUserID(context.Context, uuid.UUID) ([]models.Waitlist, error)
+	FindByUUID(context.Context, string) (*models.Waitlist, error)
+	FindAllWaitingByFieldScheduleIDsForUpdate(context.Context, *gorm.DB, []uint) ([]models.Waitlist, error)
+	CountActiveByFieldScheduleIDAndUserID(context.Context, uint, uuid.UUID) (int64, error)
+	Create(context.Context, *models.Waitlist) (*models.Waitlist, error)
+	Offer(context.Context, *gorm.DB, uint, time.Time) error
+	UpdateStatus(context.Context, *gorm.DB, constants.WaitlistStatus, []uint) error
+	UpdateStatusByFieldScheduleIDs(context.Context, *gorm.DB, []uint, *uuid.UUID, constants.WaitlistStatus, constants.WaitlistStatus) error
+	ExpireBeforeDate(context.Context, string) (int64, error)
+}
+
+// preload schedule beserta time yang mungkin sudah dihapus
+func unscoped(db *gorm.DB) *gorm.DB {
+	return db.Unscoped()
+}
+
+func NewWaitlistRepository(db *gorm.DB) IWaitlistRepository {
+	return &WaitlistRepository{db: db}
+}
+
+func (w *WaitlistRepository) FindAllByUserID(ctx context.Context, userID uuid.UUID) ([]models.Waitlist, error) {
+	var waitlists []models.Waitlist
+	err := w.db.
+		WithContext(ctx).
+		Preload("FieldSchedule").
+		Preload("FieldSchedule.Field").
+		Preload("FieldSchedule.Time", unscoped).
+		Where("user_id = ?", userID).
+		Order("created_at desc").
+		Find(&waitlists).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return waitlists, nil
+}
+
+func (w *WaitlistRepository) FindByUUID(ctx context.Context, uuid string) (*models.Waitlist, error) {
+	var waitlist models.Waitlist
+	err := w.db.
+		WithContext(ctx).
+		Preload("FieldSchedule").
+		Preload("FieldSchedule.Field").
+		Preload("FieldSchedule.Time", unscoped).
+		Where("uuid = ?", uuid).
+		First(&waitlist).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errWrap.WrapError(errWaitlist.ErrWaitlistNotFound)
+		}
+
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &waitlist, nil
+}
+
+// FindAllWaitingByFieldScheduleIDsForUpdate mengunci antrian yang masih menunggu,
+// urutan created_at menentukan siapa yang mendapat tawaran lebih dulu.
+func (w *WaitlistRepository) FindAllWaitingByFieldScheduleIDsForUpdate(
+	ctx context.Context,
+	tx *gorm.DB,
+	fieldScheduleIDs []uint,
+) ([]models.Waitlist, error) {
+	var waitlists []models.Waitlist
+	err := tx.
+		WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("field_schedule_id IN ?", fieldScheduleIDs).
+		Where("status = ?", constants.WaitlistWaiting).
+		Order("created_at asc").
+		Order("id asc").
+		Find(&waitlists).
+		Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return waitlists, nil
+}
+
+func (w *WaitlistRepository) CountActiveByFieldScheduleIDAndUserID(
+	ctx context.Context,
+	fieldScheduleID uint,
+	userID uuid.UUID,
+) (int64, error) {
+	var total int64
+	err := w.db.
+		WithContext(ctx).
+		Model(&models.Waitlist{}).
+		Where("field_schedule_id = ?", fieldScheduleID).
+		Where("user_id = ?", userID).
+		Where("status IN ?", []constants.WaitlistStatus{constants.WaitlistWaiting, constants.WaitlistOffered}).
+		Count(&total).
+		Error
+	if err != nil {
+		return 0, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return total, nil
+}
+
+func (w *WaitlistRepository) Create(ctx context.Context, req *models.Waitlist) (*models.Waitlist, error) {
+	waitlist := models.Waitlist{
+		UUID:            uuid.New(),
+		FieldScheduleID: req.FieldScheduleID,
+		UserID:          req.UserID,
+		Status:          constants.WaitlistWaiting,
+	}
+
+	err := w.db.WithContext(ctx).Create(&waitlist).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return &waitlist, nil
+}
+
+func (w *WaitlistRepository) Offer(ctx context.Context, tx *gorm.DB, id uint, offeredUntil time.Time) error {
+	err := tx.
+		WithContext(ctx).
+		Model(&models.Waitlist{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":        constants.WaitlistOffered,
+			"offered_until": offeredUntil,
+		}).
+		Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+func (w *WaitlistRepository) UpdateStatus(ctx context.Context, tx *gorm.DB, status constants.WaitlistStatus, ids []uint) error {
+	err := tx.
+		WithContext(ctx).
+		Model(&models.Waitlist{}).
+		Where("id IN ?", ids).
+		Update("status", status).
+		Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+// UpdateStatusByFieldScheduleIDs mengubah status antrian milik schedule tersebut dari fromStatus ke toStatus,
+// userID kosong berarti berlaku untuk semua user.
+func (w *WaitlistRepository) UpdateStatusByFieldScheduleIDs(
+	ctx context.Context,
+	tx *gorm.DB,
+	fieldScheduleIDs []uint,
+	userID *uuid.UUID,
+	fromStatus, toStatus constants.WaitlistStatus,
+) error {
+	query := tx.
+		WithContext(ctx).
+		Model(&models.Waitlist{}).
+		Where("field_schedule_id IN ?", fieldScheduleIDs).
+		Where("status = ?", fromStatus)
+	if userID != nil {
+		query = query.Where("user_id = ?", *userID)
+	}
+
+	err := query.Update("status", toStatus).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return nil
+}
+
+// ExpireBeforeDate mengakhiri antrian yang tanggal schedule-nya sudah lewat.
+func (w *WaitlistRepository) ExpireBeforeDate(ctx context.Context, date string) (int64, error) {
+	result := w.db.
+		WithContext(ctx).
+		Model(&models.Waitlist{}).
+		Where("status IN ?", []constants.WaitlistStatus{constants.WaitlistWaiting, constants.WaitlistOffered}).
+		Where("field_schedule_id IN (?)", w.db.Unscoped().Model(&models.FieldSchedule{}).Select("id").Where("date < ?", date)).
+		Update("status", constants.WaitlistExpired)
+	if result.Error != nil {
+		return 0, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	return result.RowsAffected, nil
+}
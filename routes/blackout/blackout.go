@@ -0,0 +1,47 @@
+package routes
+
+import (
+	"field-service/clients"
+	"field-service/constants"
+	"field-service/controllers"
+	"field-service/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+type BlackoutRoute struct {
+	controller controllers.IControllerRegistry
+	group      *gin.RouterGroup
+	client     clients.IClientRegistry
+}
+
+type IBlackoutRoute interface {
+	Run()
+}
+
+func NewBlackoutRoute(group *gin.RouterGroup, controller controllers.IControllerRegistry, client clients.IClientRegistry) *BlackoutRoute {
+	return &BlackoutRoute{
+		controller: controller,
+		group:      group,
+		client:     client,
+	}
+}
+
+func (b *BlackoutRoute) Run() {
+	group := b.group.Group("/blackout")
+	group.Use(middlewares.Authenticate())
+	group.GET("", middlewares.CheckRole([]string{
+		constants.Admin,
+	}, b.client), b.controller.GetBlackout().GetAll)
+	group.GET("/:uuid", middlewares.CheckRole([]string{
+		constants.Admin,
+	}, b.client), b.controller.GetBlackout().GetByUUID)
+	group.POST("", middlewares.CheckRole([]string{
+		constants.Admin,
+	}, b.client), b.controller.GetBlackout().Create)
+	group.PUT("/:uuid", middlewares.CheckRole([]string{
+		constants.Admin,
+	}, b.client), b.controller.GetBlackout().Update)
+	group.DELETE("/:uuid", middlewares.CheckRole([]string{
+		constants.Admin,
+	}, b.client), b.controller.GetBlackout().Delete)
+}
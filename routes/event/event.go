@@ -0,0 +1,44 @@
+package routes
+
+import (
+	"field-service/clients"
+	"field-service/constants"
+	"field-service/controllers"
+	"field-service/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+type EventRoute struct {
+	controller controllers.IControllerRegistry
+	group      *gin.RouterGroup
+	client     clients.IClientRegistry
+}
+
+type IEventRoute interface {
+	Run()
+}
+
+func NewEventRoute(group *gin.RouterGroup, controller controllers.IControllerRegistry, client clients.IClientRegistry) *EventRoute {
+	return &EventRoute{
+		controller: controller,
+		group:      group,
+		client:     client,
+	}
+}
+
+func (e *EventRoute) Run() {
+	group := e.group.Group("/field/schedule/event")
+	group.Use(middlewares.Authenticate())
+	group.GET("", middlewares.CheckRole([]string{
+		constants.Admin,
+	}, e.client), e.controller.GetEvent().GetAll)
+	group.GET("/:uuid", middlewares.CheckRole([]string{
+		constants.Admin,
+	}, e.client), e.controller.GetEvent().GetByUUID)
+	group.POST("", middlewares.CheckRole([]string{
+		constants.Admin,
+	}, e.client), e.controller.GetEvent().Create)
+	group.DELETE("/:uuid", middlewares.CheckRole([]string{
+		constants.Admin,
+	}, e.client), e.controller.GetEvent().Cancel)
+}
@@ -29,7 +29,12 @@ func NewFieldScheduleRoute(group *gin.RouterGroup, controller controllers.IContr
 func (f *FieldScheduleRoute) Run() {
 	group := f.group.Group("/field/schedule")
 	group.GET("/lists/:uuid", middlewares.AuthenticateWithoutToken(), f.controller.GetFieldSchedule().GetAllByFieldIDAndDate)
+	group.GET("/calendar/:uuid", middlewares.AuthenticateWithoutToken(), f.controller.GetFieldSchedule().GetCalendarByFieldID)
+	group.GET("/search", middlewares.AuthenticateWithoutToken(), f.controller.GetFieldSchedule().Search)
 	group.PATCH("/status", middlewares.AuthenticateWithoutToken(), f.controller.GetFieldSchedule().UpdateStatus)
+	group.PATCH("/hold", middlewares.AuthenticateWithoutToken(), f.controller.GetFieldSchedule().Hold)
+	group.PATCH("/release", middlewares.AuthenticateWithoutToken(), f.controller.GetFieldSchedule().Release)
+	group.POST("/quote", middlewares.AuthenticateWithoutToken(), f.controller.GetFieldSchedule().Quote)
 	group.Use(middlewares.Authenticate())
 	group.GET("/pagination", middlewares.CheckRole([]string{
 		constants.Admin,
@@ -49,6 +54,14 @@ func (f *FieldScheduleRoute) Run() {
 		constants.Admin,
 	}, f.client),
 		f.controller.GetFieldSchedule().GenerateScheduleForOneMonth)
+	group.PATCH("/maintenance", middlewares.CheckRole([]string{
+		constants.Admin,
+	}, f.client),
+		f.controller.GetFieldSchedule().StartMaintenance)
+	group.PATCH("/maintenance/finish", middlewares.CheckRole([]string{
+		constants.Admin,
+	}, f.client),
+		f.controller.GetFieldSchedule().FinishMaintenance)
 	group.PUT("/:uuid", middlewares.CheckRole([]string{
 		constants.Admin,
 	}, f.client),
@@ -0,0 +1,49 @@
+package routes
+
+import (
+	"field-service/clients"
+	"field-service/constants"
+	"field-service/controllers"
+	"field-service/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+type PromoRoute struct {
+	controller controllers.IControllerRegistry
+	group      *gin.RouterGroup
+	client     clients.IClientRegistry
+}
+
+type IPromoRoute interface {
+	Run()
+}
+
+func NewPromoRoute(group *gin.RouterGroup, controller controllers.IControllerRegistry, client clients.IClientRegistry) *PromoRoute {
+	return &PromoRoute{
+		controller: controller,
+		group:      group,
+		client:     client,
+	}
+}
+
+func (p *PromoRoute) Run() {
+	group := p.group.Group("/promo")
+	group.POST("/evaluate", middlewares.AuthenticateWithoutToken(), p.controller.GetPromo().Evaluate)
+	group.POST("/redeem", middlewares.AuthenticateWithoutToken(), p.controller.GetPromo().Redeem)
+	group.Use(middlewares.Authenticate())
+	group.GET("", middlewares.CheckRole([]string{
+		constants.Admin,
+	}, p.client), p.controller.GetPromo().GetAll)
+	group.GET("/:uuid", middlewares.CheckRole([]string{
+		constants.Admin,
+	}, p.client), p.controller.GetPromo().GetByUUID)
+	group.POST("", middlewares.CheckRole([]string{
+		constants.Admin,
+	}, p.client), p.controller.GetPromo().Create)
+	group.PUT("/:uuid", middlewares.CheckRole([]string{
+		constants.Admin,
+	}, p.client), p.controller.GetPromo().Update)
+	group.DELETE("/:uuid", middlewares.CheckRole([]string{
+		constants.Admin,
+	}, p.client), p.controller.GetPromo().Delete)
+}
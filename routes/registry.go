@@ -3,9 +3,17 @@ package routes
 import (
 	"field-service/clients"
 	"field-service/controllers"
+	blackoutRoute "field-service/routes/blackout"
+	eventRoute "field-service/routes/event"
 	fieldRoute "field-service/routes/field"
 	fieldScheduleRoute "field-service/routes/field_schedule"
+	pricingRuleRoute "field-service/routes/pricing_rule"
+	promoRoute "field-service/routes/promo"
+	scheduleRuleRoute "field-service/routes/schedule_rule"
+	standingReservationRoute "field-service/routes/standing_reservation"
 	timeRoute "field-service/routes/time"
+	timeSetRoute "field-service/routes/time_set"
+	waitlistRoute "field-service/routes/waitlist"
 	"github.com/gin-gonic/gin"
 )
 
@@ -31,6 +39,14 @@ func (r *Registry) Serve() {
 	r.fieldRoute().Run()
 	r.fieldScheduleRoute().Run()
 	r.timeRoute().Run()
+	r.scheduleRuleRoute().Run()
+	r.blackoutRoute().Run()
+	r.timeSetRoute().Run()
+	r.pricingRuleRoute().Run()
+	r.promoRoute().Run()
+	r.waitlistRoute().Run()
+	r.standingReservationRoute().Run()
+	r.eventRoute().Run()
 }
 
 func (r *Registry) fieldRoute() fieldRoute.IFieldRoute {
@@ -44,3 +60,35 @@ func (r *Registry) fieldScheduleRoute() fieldScheduleRoute.IFieldScheduleRoute {
 func (r *Registry) timeRoute() timeRoute.ITimeRoute {
 	return timeRoute.NewTimeRoute(r.group, r.controller, r.client)
 }
+
+func (r *Registry) scheduleRuleRoute() scheduleRuleRoute.IScheduleRuleRoute {
+	return scheduleRuleRoute.NewScheduleRuleRoute(r.group, r.controller, r.client)
+}
+
+func (r *Registry) blackoutRoute() blackoutRoute.IBlackoutRoute {
+	return blackoutRoute.NewBlackoutRoute(r.group, r.controller, r.client)
+}
+
+func (r *Registry) timeSetRoute() timeSetRoute.ITimeSetRoute {
+	return timeSetRoute.NewTimeSetRoute(r.group, r.controller, r.client)
+}
+
+func (r *Registry) pricingRuleRoute() pricingRuleRoute.IPricingRuleRoute {
+	return pricingRuleRoute.NewPricingRuleRoute(r.group, r.controller, r.client)
+}
+
+func (r *Registry) promoRoute() promoRoute.IPromoRoute {
+	return promoRoute.NewPromoRoute(r.group, r.controller, r.client)
+}
+
+func (r *Registry) waitlistRoute() waitlistRoute.IWaitlistRoute {
+	return waitlistRoute.NewWaitlistRoute(r.group, r.controller, r.client)
+}
+
+func (r *Registry) standingReservationRoute() standingReservationRoute.IStandingReservationRoute {
+	return standingReservationRoute.NewStandingReservationRoute(r.group, r.controller, r.client)
+}
+
+func (r *Registry) eventRoute() eventRoute.IEventRoute {
+	return eventRoute.NewEventRoute(r.group, r.controller, r.client)
+}
@@ -0,0 +1,47 @@
+package routes
+
+import (
+	"field-service/clients"
+	"field-service/constants"
+	"field-service/controllers"
+	"field-service/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+type ScheduleRuleRoute struct {
+	controller controllers.IControllerRegistry
+	group      *gin.RouterGroup
+	client     clients.IClientRegistry
+}
+
+type IScheduleRuleRoute interface {
+	Run()
+}
+
+func NewScheduleRuleRoute(group *gin.RouterGroup, controller controllers.IControllerRegistry, client clients.IClientRegistry) *ScheduleRuleRoute {
+	return &ScheduleRuleRoute{
+		controller: controller,
+		group:      group,
+		client:     client,
+	}
+}
+
+func (s *ScheduleRuleRoute) Run() {
+	group := s.group.Group("/field/schedule/rule")
+	group.Use(middlewares.Authenticate())
+	group.GET("", middlewares.CheckRole([]string{
+		constants.Admin,
+	}, s.client), s.controller.GetScheduleRule().GetAll)
+	group.GET("/:uuid", middlewares.CheckRole([]string{
+		constants.Admin,
+	}, s.client), s.controller.GetScheduleRule().GetByUUID)
+	group.POST("", middlewares.CheckRole([]string{
+		constants.Admin,
+	}, s.client), s.controller.GetScheduleRule().Create)
+	group.PUT("/:uuid", middlewares.CheckRole([]string{
+		constants.Admin,
+	}, s.client), s.controller.GetScheduleRule().Update)
+	group.DELETE("/:uuid", middlewares.CheckRole([]string{
+		constants.Admin,
+	}, s.client), s.controller.GetScheduleRule().Delete)
+}
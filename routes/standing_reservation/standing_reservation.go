@@ -0,0 +1,47 @@
+package routes
+
+import (
+	"field-service/clients"
+	"field-service/constants"
+	"field-service/controllers"
+	"field-service/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+type StandingReservationRoute struct {
+	controller controllers.IControllerRegistry
+	group      *gin.RouterGroup
+	client     clients.IClientRegistry
+}
+
+type IStandingReservationRoute interface {
+	Run()
+}
+
+func NewStandingReservationRoute(group *gin.RouterGroup, controller controllers.IControllerRegistry, client clients.IClientRegistry) *StandingReservationRoute {
+	return &StandingReservationRoute{
+		controller: controller,
+		group:      group,
+		client:     client,
+	}
+}
+
+func (s *StandingReservationRoute) Run() {
+	group := s.group.Group("/field/schedule/standing")
+	group.Use(middlewares.Authenticate())
+	group.GET("", middlewares.CheckRole([]string{
+		constants.Admin,
+		constants.Customer,
+	}, s.client), s.controller.GetStandingReservation().GetAll)
+	group.GET("/:uuid", middlewares.CheckRole([]string{
+		constants.Admin,
+		constants.Customer,
+	}, s.client), s.controller.GetStandingReservation().GetByUUID)
+	group.POST("", middlewares.CheckRole([]string{
+		constants.Admin,
+	}, s.client), s.controller.GetStandingReservation().Create)
+	group.DELETE("/:uuid", middlewares.CheckRole([]string{
+		constants.Admin,
+		constants.Customer,
+	}, s.client), s.controller.GetStandingReservation().Cancel)
+}
@@ -0,0 +1,47 @@
+package routes
+
+import (
+	"field-service/clients"
+	"field-service/constants"
+	"field-service/controllers"
+	"field-service/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+type TimeSetRoute struct {
+	controller controllers.IControllerRegistry
+	group      *gin.RouterGroup
+	client     clients.IClientRegistry
+}
+
+type ITimeSetRoute interface {
+	Run()
+}
+
+func NewTimeSetRoute(group *gin.RouterGroup, controller controllers.IControllerRegistry, client clients.IClientRegistry) *TimeSetRoute {
+	return &TimeSetRoute{
+		controller: controller,
+		group:      group,
+		client:     client,
+	}
+}
+
+func (t *TimeSetRoute) Run() {
+	group := t.group.Group("/time/set")
+	group.Use(middlewares.Authenticate())
+	group.GET("", middlewares.CheckRole([]string{
+		constants.Admin,
+	}, t.client), t.controller.GetTimeSet().GetAll)
+	group.GET("/:uuid", middlewares.CheckRole([]string{
+		constants.Admin,
+	}, t.client), t.controller.GetTimeSet().GetByUUID)
+	group.POST("", middlewares.CheckRole([]string{
+		constants.Admin,
+	}, t.client), t.controller.GetTimeSet().Create)
+	group.PUT("/:uuid", middlewares.CheckRole([]string{
+		constants.Admin,
+	}, t.client), t.controller.GetTimeSet().Update)
+	group.DELETE("/:uuid", middlewares.CheckRole([]string{
+		constants.Admin,
+	}, t.client), t.controller.GetTimeSet().Delete)
+}
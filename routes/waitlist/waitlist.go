@@ -0,0 +1,44 @@
+package routes
+
+import (
+	"field-service/clients"
+	"field-service/constants"
+	"field-service/controllers"
+	"field-service/middlewares"
+	"github.com/gin-gonic/gin"
+)
+
+type WaitlistRoute struct {
+	controller controllers.IControllerRegistry
+	group      *gin.RouterGroup
+	client     clients.IClientRegistry
+}
+
+type IWaitlistRoute interface {
+	Run()
+}
+
+func NewWaitlistRoute(group *gin.RouterGroup, controller controllers.IControllerRegistry, client clients.IClientRegistry) *WaitlistRoute {
+	return &WaitlistRoute{
+		controller: controller,
+		group:      group,
+		client:     client,
+	}
+}
+
+func (w *WaitlistRoute) Run() {
+	group := w.group.Group("/field/schedule/waitlist")
+	group.Use(middlewares.Authenticate())
+	group.GET("", middlewares.CheckRole([]string{
+		constants.Admin,
+		constants.Customer,
+	}, w.client), w.controller.GetWaitlist().GetAll)
+	group.POST("", middlewares.CheckRole([]string{
+		constants.Admin,
+		constants.Customer,
+	}, w.client), w.controller.GetWaitlist().Join)
+	group.DELETE("/:uuid", middlewares.CheckRole([]string{
+		constants.Admin,
+		constants.Customer,
+	}, w.client), w.controller.GetWaitlist().Cancel)
+}
@@ -0,0 +1,225 @@
+package services
+
+import (
+	"context"
+	"field-service/constants"
+	errFieldSchedule "field-service/constants/error/fieldSchedule"
+	"field-service/domain/dto"
+	"field-service/domain/models"
+	"field-service/repositories"
+	"fmt"
+	"gorm.io/gorm"
+	"time"
+)
+
+type BlackoutService struct {
+	repository repositories.IRepositoryRegistry
+}
+
+type IBlackoutService interface {
+	GetAll(context.Context) ([]dto.BlackoutResponse, error)
+	GetByUUID(context.Context, string) (*dto.BlackoutResponse, error)
+	Create(context.Context, *dto.BlackoutRequest) (*dto.BlackoutResponse, error)
+	Update(context.Context, string, *dto.BlackoutRequest) (*dto.BlackoutResponse, error)
+	Delete(context.Context, string) error
+}
+
+func NewBlackoutService(repository repositories.IRepositoryRegistry) IBlackoutService {
+	return &BlackoutService{repository: repository}
+}
+
+func (b *BlackoutService) toResponse(blackout *models.Blackout, field *models.Field) dto.BlackoutResponse {
+	response := dto.BlackoutResponse{
+		UUID:      blackout.UUID,
+		Name:      blackout.Name,
+		StartDate: blackout.StartDate.Format(time.DateOnly),
+		EndDate:   blackout.EndDate.Format(time.DateOnly),
+		CreatedAt: blackout.CreatedAt,
+		UpdatedAt: blackout.UpdatedAt,
+	}
+
+	if field != nil {
+		response.FieldID = &field.UUID
+		response.FieldName = field.Name
+	}
+
+	return response
+}
+
+func (b *BlackoutService) GetAll(ctx context.Context) ([]dto.BlackoutResponse, error) {
+	blackouts, err := b.repository.GetBlackout().FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	blackoutResults := make([]dto.BlackoutResponse, 0, len(blackouts))
+	for _, blackout := range blackouts {
+		blackoutResults = append(blackoutResults, b.toResponse(&blackout, blackout.Field))
+	}
+
+	return blackoutResults, nil
+}
+
+func (b *BlackoutService) GetByUUID(ctx context.Context, uuid string) (*dto.BlackoutResponse, error) {
+	blackout, err := b.repository.GetBlackout().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	response := b.toResponse(blackout, blackout.Field)
+	return &response, nil
+}
+
+func (b *BlackoutService) buildBlackout(ctx context.Context, request *dto.BlackoutRequest) (*models.Blackout, *models.Field, error) {
+	var field *models.Field
+	if request.FieldID != "" {
+		fieldResult, err := b.repository.GetField().FindByUUID(ctx, request.FieldID)
+		if err != nil {
+			return nil, nil, err
+		}
+		field = fieldResult
+	}
+
+	startDate, err := time.ParseInLocation(time.DateOnly, request.StartDate, time.Local)
+	if err != nil {
+		return nil, nil, errFieldSchedule.ErrInvalidDate
+	}
+
+	endDate, err := time.ParseInLocation(time.DateOnly, request.EndDate, time.Local)
+	if err != nil {
+		return nil, nil, errFieldSchedule.ErrInvalidDate
+	}
+
+	if endDate.Before(startDate) {
+		return nil, nil, errFieldSchedule.ErrInvalidDateRange
+	}
+
+	blackout := &models.Blackout{
+		Name:      request.Name,
+		StartDate: startDate,
+		EndDate:   endDate,
+	}
+	if field != nil {
+		blackout.FieldID = &field.ID
+	}
+
+	return blackout, field, nil
+}
+
+// closeFieldSchedules menghapus schedule available yang tertutup blackout dan
+// mengembalikan schedule yang sudah dipesan sebagai konflik.
+func (b *BlackoutService) closeFieldSchedules(
+	ctx context.Context,
+	tx *gorm.DB,
+	blackout *models.Blackout,
+) ([]dto.FieldScheduleConflictResponse, error) {
+	fieldSchedules, err := b.repository.GetFieldSchedule().FindAllByDateRangeForUpdate(
+		ctx,
+		tx,
+		blackout.FieldID,
+		blackout.StartDate.Format(time.DateOnly),
+		blackout.EndDate.Format(time.DateOnly),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	ids := make([]uint, 0, len(fieldSchedules))
+	conflicts := make([]dto.FieldScheduleConflictResponse, 0)
+	for _, fieldSchedule := range fieldSchedules {
+		isHoldExpired := fieldSchedule.Status == constants.Held &&
+			fieldSchedule.HeldUntil != nil &&
+			!fieldSchedule.HeldUntil.After(now)
+		if fieldSchedule.Status == constants.Available || isHoldExpired {
+			ids = append(ids, fieldSchedule.ID)
+			continue
+		}
+
+		conflicts = append(conflicts, dto.FieldScheduleConflictResponse{
+			UUID:   fieldSchedule.UUID,
+			Date:   fieldSchedule.Date.Format(time.DateOnly),
+			Time:   fmt.Sprintf("%s - %s", fieldSchedule.Time.StartTime, fieldSchedule.Time.EndTime),
+			Status: fieldSchedule.Status.GetStatusString(),
+		})
+	}
+
+	if len(ids) > 0 {
+		err = b.repository.GetFieldSchedule().DeleteByIDs(ctx, tx, ids)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return conflicts, nil
+}
+
+func (b *BlackoutService) Create(ctx context.Context, request *dto.BlackoutRequest) (*dto.BlackoutResponse, error) {
+	blackout, field, err := b.buildBlackout(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		blackoutCreated *models.Blackout
+		conflicts       []dto.FieldScheduleConflictResponse
+	)
+	err = b.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		blackoutCreated, err = b.repository.GetBlackout().Create(ctx, tx, blackout)
+		if err != nil {
+			return err
+		}
+
+		conflicts, err = b.closeFieldSchedules(ctx, tx, blackoutCreated)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := b.toResponse(blackoutCreated, field)
+	response.Conflicts = conflicts
+	return &response, nil
+}
+
+func (b *BlackoutService) Update(ctx context.Context, uuid string, request *dto.BlackoutRequest) (*dto.BlackoutResponse, error) {
+	blackout, field, err := b.buildBlackout(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		blackoutUpdated *models.Blackout
+		conflicts       []dto.FieldScheduleConflictResponse
+	)
+	err = b.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		blackoutUpdated, err = b.repository.GetBlackout().Update(ctx, tx, uuid, blackout)
+		if err != nil {
+			return err
+		}
+
+		conflicts, err = b.closeFieldSchedules(ctx, tx, blackoutUpdated)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := b.toResponse(blackoutUpdated, field)
+	response.Conflicts = conflicts
+	return &response, nil
+}
+
+func (b *BlackoutService) Delete(ctx context.Context, uuid string) error {
+	_, err := b.repository.GetBlackout().FindByUUID(ctx, uuid)
+	if err != nil {
+		return err
+	}
+
+	err = b.repository.GetBlackout().Delete(ctx, uuid)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
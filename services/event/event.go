@@ -0,0 +1,501 @@
+package services
+
+import (
+	"context"
+	clientUser "field-service/clients/user"
+	"field-service/common/pricing"
+	"field-service/common/util"
+	"field-service/constants"
+	errConstant "field-service/constants/error"
+	errBlackout "field-service/constants/error/blackout"
+	errEvent "field-service/constants/error/event"
+	errFieldSchedule "field-service/constants/error/fieldSchedule"
+	errTime "field-service/constants/error/time"
+	"field-service/domain/dto"
+	"field-service/domain/models"
+	"field-service/repositories"
+	fieldScheduleService "field-service/services/field_schedule"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+type EventService struct {
+	repository    repositories.IRepositoryRegistry
+	fieldSchedule fieldScheduleService.IFieldScheduleLocker
+}
+
+type IEventService interface {
+	GetAll(context.Context) ([]dto.EventResponse, error)
+	GetByUUID(context.Context, string) (*dto.EventResponse, error)
+	Create(context.Context, *dto.EventRequest) (*dto.EventResponse, error)
+	Cancel(context.Context, string) error
+}
+
+func NewEventService(
+	repository repositories.IRepositoryRegistry,
+	fieldSchedule fieldScheduleService.IFieldScheduleLocker,
+) IEventService {
+	return &EventService{repository: repository, fieldSchedule: fieldSchedule}
+}
+
+func (e *EventService) currentUsername(ctx context.Context) string {
+	user, ok := ctx.Value(constants.User).(*clientUser.UserData)
+	if !ok {
+		return ""
+	}
+
+	return user.Username
+}
+
+// currentUserID mengembalikan user yang membuat event, slot event di-booking atas nama user tersebut.
+func (e *EventService) currentUserID(ctx context.Context) (*uuid.UUID, error) {
+	user, ok := ctx.Value(constants.User).(*clientUser.UserData)
+	if !ok {
+		return nil, errConstant.ErrUnauthorized
+	}
+
+	return &user.UUID, nil
+}
+
+func (e *EventService) toResponse(event *models.Event) dto.EventResponse {
+	fields := make([]dto.FieldResponse, 0, len(event.Fields))
+	for _, field := range event.Fields {
+		fields = append(fields, dto.FieldResponse{
+			UUID:         field.UUID,
+			Code:         field.Code,
+			Name:         field.Name,
+			PricePerHour: field.PricePerHour,
+			BufferMinute: field.BufferMinute,
+			Images:       field.Images,
+			CreatedAt:    field.CreatedAt,
+			UpdatedAt:    field.UpdatedAt,
+		})
+	}
+
+	return dto.EventResponse{
+		UUID:        event.UUID,
+		Name:        event.Name,
+		Fields:      fields,
+		StartDate:   event.StartDate.Format(time.DateOnly),
+		EndDate:     event.EndDate.Format(time.DateOnly),
+		StartTime:   event.StartTime,
+		EndTime:     event.EndTime,
+		CreatedBy:   event.CreatedBy,
+		CancelledAt: event.CancelledAt,
+		CreatedAt:   event.CreatedAt,
+		UpdatedAt:   event.UpdatedAt,
+	}
+}
+
+func (e *EventService) fieldIDs(event *models.Event) []uint {
+	fieldIDs := make([]uint, 0, len(event.Fields))
+	for _, field := range event.Fields {
+		fieldIDs = append(fieldIDs, field.ID)
+	}
+
+	return fieldIDs
+}
+
+func (e *EventService) GetAll(ctx context.Context) ([]dto.EventResponse, error) {
+	events, err := e.repository.GetEvent().FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	eventResults := make([]dto.EventResponse, 0, len(events))
+	for _, event := range events {
+		eventResults = append(eventResults, e.toResponse(&event))
+	}
+
+	return eventResults, nil
+}
+
+func (e *EventService) GetByUUID(ctx context.Context, uuid string) (*dto.EventResponse, error) {
+	event, err := e.repository.GetEvent().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	response := e.toResponse(event)
+	return &response, nil
+}
+
+func (e *EventService) buildEvent(ctx context.Context, request *dto.EventRequest) (*models.Event, error) {
+	startDate, err := util.ParseDate(request.StartDate)
+	if err != nil {
+		return nil, err
+	}
+
+	endDate, err := util.ParseDate(request.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	if endDate.Before(startDate) {
+		return nil, errFieldSchedule.ErrInvalidDateRange
+	}
+
+	if startDate.Format(time.DateOnly) < time.Now().Format(time.DateOnly) {
+		return nil, errFieldSchedule.ErrFieldScheduleInPast
+	}
+
+	if endDate.After(startDate.AddDate(0, 0, constants.MaxGenerateScheduleNumberOfDays-1)) {
+		return nil, errFieldSchedule.ErrDateRangeTooLong
+	}
+
+	// tanpa jam berarti event memakai field sepanjang hari
+	startTime, endTime := "00:00:00", "24:00:00"
+	if request.StartTime != "" {
+		startTime, err = util.NormalizeTime(request.StartTime)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if request.EndTime != "" {
+		endTime, err = util.NormalizeTime(request.EndTime)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if endTime <= startTime {
+		return nil, errTime.ErrInvalidTimeRange
+	}
+
+	fields := make([]models.Field, 0, len(request.FieldIDs))
+	for _, fieldID := range util.UniqueFieldScheduleIDs(request.FieldIDs) {
+		field, err := e.repository.GetField().FindByUUID(ctx, fieldID)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, *field)
+	}
+
+	event := &models.Event{
+		Name:      request.Name,
+		StartDate: startDate,
+		EndDate:   endDate,
+		StartTime: startTime,
+		EndTime:   endTime,
+		CreatedBy: e.currentUsername(ctx),
+		Fields:    fields,
+	}
+
+	return event, nil
+}
+
+// eventSchedules mengunci schedule setiap field event dalam rentang tanggal dan jam event.
+// Schedule available dikembalikan untuk dipesan, slot yang belum dibuat dikembalikan sebagai schedule baru,
+// dan schedule yang sudah dipesan dilaporkan sebagai konflik. Schedule rule tidak dipakai karena
+// event memesan seluruh jam yang diminta.
+func (e *EventService) eventSchedules(
+	ctx context.Context,
+	tx *gorm.DB,
+	event *models.Event,
+) ([]models.FieldSchedule, []models.FieldSchedule, []dto.FieldScheduleConflictResponse, error) {
+	now := time.Now()
+	startDate := event.StartDate.Format(time.DateOnly)
+	endDate := event.EndDate.Format(time.DateOnly)
+	reserved := make([]models.FieldSchedule, 0)
+	created := make([]models.FieldSchedule, 0)
+	conflicts := make([]dto.FieldScheduleConflictResponse, 0)
+	for i := range event.Fields {
+		field := &event.Fields[i]
+		blackouts, err := e.repository.GetBlackout().FindAllByFieldIDAndDateRange(ctx, int(field.ID), startDate, endDate)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		if len(blackouts) > 0 {
+			return nil, nil, nil, errBlackout.ErrDateIsBlackout
+		}
+
+		times, err := e.repository.GetTime().FindAllByTimeSetID(ctx, field.TimeSetID)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		eventTimes := make([]models.Time, 0, len(times))
+		for _, item := range times {
+			itemStartTime, err := util.NormalizeTime(item.StartTime)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+
+			itemEndTime, err := util.NormalizeTime(item.EndTime)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+
+			if itemStartTime < event.EndTime && itemEndTime > event.StartTime {
+				eventTimes = append(eventTimes, item)
+			}
+		}
+
+		fieldSchedules, err := e.repository.GetFieldSchedule().FindAllByDateRangeForUpdate(ctx, tx, &field.ID, startDate, endDate)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		existing := make(map[string]models.FieldSchedule, len(fieldSchedules))
+		for _, fieldSchedule := range fieldSchedules {
+			existing[util.ScheduleKey(fieldSchedule.Date, fieldSchedule.TimeID)] = fieldSchedule
+		}
+
+		pricingRules, err := e.repository.GetPricingRule().FindAllByFieldIDs(ctx, []uint{field.ID})
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		for currentDate := event.StartDate; !currentDate.After(event.EndDate); currentDate = currentDate.AddDate(0, 0, 1) {
+			for _, timeItem := range eventTimes {
+				fieldSchedule, ok := existing[util.ScheduleKey(currentDate, timeItem.ID)]
+				if !ok {
+					fieldSchedule = models.FieldSchedule{
+						UUID:    uuid.New(),
+						FieldID: field.ID,
+						TimeID:  timeItem.ID,
+						Date:    currentDate,
+						Status:  constants.Booked,
+						Time:    timeItem,
+					}
+				}
+
+				// slot hari ini yang sudah dimulai tidak ikut dipesan
+				startAt, err := util.ScheduleStartAt(&fieldSchedule)
+				if err != nil {
+					return nil, nil, nil, err
+				}
+
+				if !startAt.After(now) {
+					continue
+				}
+
+				if !ok {
+					err = pricing.Snapshot(&fieldSchedule, field, &timeItem, pricingRules)
+					if err != nil {
+						return nil, nil, nil, err
+					}
+					created = append(created, fieldSchedule)
+					continue
+				}
+
+				if fieldSchedule.Status != constants.Available && !util.IsHoldExpired(fieldSchedule, now) {
+					conflicts = append(conflicts, dto.FieldScheduleConflictResponse{
+						UUID:      fieldSchedule.UUID,
+						FieldName: field.Name,
+						Date:      fieldSchedule.Date.Format(time.DateOnly),
+						Time:      fmt.Sprintf("%s - %s", fieldSchedule.Time.StartTime, fieldSchedule.Time.EndTime),
+						Status:    fieldSchedule.Status.GetStatusString(),
+					})
+					continue
+				}
+
+				reserved = append(reserved, fieldSchedule)
+			}
+		}
+	}
+
+	return reserved, created, conflicts, nil
+}
+
+// Create memesan semua slot field event dalam satu transaksi, jika ada satu slot yang
+// tidak available seluruh pemesanan dibatalkan.
+func (e *EventService) Create(ctx context.Context, request *dto.EventRequest) (*dto.EventResponse, error) {
+	bookedBy, err := e.currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := e.buildEvent(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	var response dto.EventResponse
+	err = e.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		err := e.fieldSchedule.LockFieldGroups(ctx, tx, e.fieldIDs(event))
+		if err != nil {
+			return err
+		}
+
+		reserved, created, conflicts, err := e.eventSchedules(ctx, tx, event)
+		if err != nil {
+			return err
+		}
+
+		if len(conflicts) > 0 {
+			response.Conflicts = conflicts
+			return errEvent.ErrEventConflict
+		}
+
+		_, conflicts, err = e.fieldSchedule.BlockLinkedSchedules(ctx, tx, append(reserved, created...))
+		if err != nil {
+			return err
+		}
+
+		if len(conflicts) > 0 {
+			response.Conflicts = conflicts
+			return errFieldSchedule.ErrLinkedFieldScheduleNotAvailable
+		}
+
+		eventCreated, err := e.repository.GetEvent().Create(ctx, tx, event)
+		if err != nil {
+			return err
+		}
+		response = e.toResponse(eventCreated)
+		response.Reserved = len(reserved) + len(created)
+
+		if len(reserved) > 0 {
+			ids := util.FieldScheduleIDs(reserved)
+			err = e.repository.GetFieldSchedule().Book(ctx, tx, ids, bookedBy)
+			if err != nil {
+				return err
+			}
+
+			err = e.repository.GetFieldSchedule().UpdateEventID(ctx, tx, &eventCreated.ID, ids)
+			if err != nil {
+				return err
+			}
+
+			err = e.fieldSchedule.CloseWaitlistOffer(ctx, tx, ids, nil)
+			if err != nil {
+				return err
+			}
+
+			err = e.fieldSchedule.SnapshotPrice(ctx, tx, reserved)
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(created) == 0 {
+			return nil
+		}
+
+		for i := range created {
+			created[i].EventID = &eventCreated.ID
+			created[i].CreatedByEvent = true
+			created[i].BookedBy = bookedBy
+			created[i].Time = models.Time{}
+		}
+
+		return e.repository.GetFieldSchedule().CreateWithTx(ctx, tx, created)
+	})
+	if err != nil {
+		if len(response.Conflicts) > 0 {
+			return &response, err
+		}
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// Cancel membatalkan event, slot yang belum dimulai dibuka kembali dan slot yang
+// dibuat khusus untuk event dihapus.
+func (e *EventService) Cancel(ctx context.Context, uuid string) error {
+	event, err := e.repository.GetEvent().FindByUUID(ctx, uuid)
+	if err != nil {
+		return err
+	}
+
+	if event.CancelledAt != nil {
+		return errEvent.ErrEventCancelled
+	}
+
+	now := time.Now()
+	return e.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		err := e.fieldSchedule.LockFieldGroups(ctx, tx, e.fieldIDs(event))
+		if err != nil {
+			return err
+		}
+
+		fieldSchedules, err := e.repository.GetFieldSchedule().FindAllByEventIDForUpdate(ctx, tx, event.ID)
+		if err != nil {
+			return err
+		}
+
+		released := make([]models.FieldSchedule, 0, len(fieldSchedules))
+		removed := make([]models.FieldSchedule, 0)
+		histories := make([]models.FieldScheduleHistory, 0, len(fieldSchedules))
+		for _, fieldSchedule := range fieldSchedules {
+			startAt, err := util.ScheduleStartAt(&fieldSchedule)
+			if err != nil {
+				return err
+			}
+
+			if fieldSchedule.Status != constants.Booked || !startAt.After(now) {
+				continue
+			}
+
+			if fieldSchedule.CreatedByEvent {
+				removed = append(removed, fieldSchedule)
+				continue
+			}
+
+			released = append(released, fieldSchedule)
+			histories = append(histories, models.FieldScheduleHistory{
+				FieldScheduleID: fieldSchedule.ID,
+				Status:          constants.Available,
+				Reason:          fmt.Sprintf("event %s cancelled", event.Name),
+				CreatedBy:       e.currentUsername(ctx),
+			})
+		}
+
+		err = e.repository.GetEvent().Cancel(ctx, tx, event.ID, now)
+		if err != nil {
+			return err
+		}
+
+		if len(released) == 0 && len(removed) == 0 {
+			return nil
+		}
+
+		if len(removed) > 0 {
+			// slot yang tidak ada sebelum event tidak dibuka, antriannya ikut diakhiri
+			ids := util.FieldScheduleIDs(removed)
+			err = e.repository.GetFieldSchedule().DeleteByIDs(ctx, tx, ids)
+			if err != nil {
+				return err
+			}
+
+			for _, status := range []constants.WaitlistStatus{constants.WaitlistWaiting, constants.WaitlistOffered} {
+				err = e.repository.GetWaitlist().UpdateStatusByFieldScheduleIDs(
+					ctx, tx, ids, nil, status, constants.WaitlistExpired,
+				)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		if len(released) > 0 {
+			ids := util.FieldScheduleIDs(released)
+			err = e.repository.GetFieldSchedule().UpdateStatus(ctx, tx, constants.Available, ids)
+			if err != nil {
+				return err
+			}
+
+			err = e.repository.GetFieldSchedule().UpdateEventID(ctx, tx, nil, ids)
+			if err != nil {
+				return err
+			}
+
+			err = e.repository.GetFieldScheduleHistory().Create(ctx, tx, histories)
+			if err != nil {
+				return err
+			}
+		}
+
+		opened, err := e.fieldSchedule.UnblockLinkedSchedules(ctx, tx, append(released, removed...))
+		if err != nil {
+			return err
+		}
+
+		return e.fieldSchedule.OfferToWaitlist(ctx, tx, append(released, opened...))
+	})
+}
@@ -4,23 +4,29 @@ import (
 	"bytes"
 	"context"
 	"field-service/common/util"
+	"field-service/constants"
 	errConstant "field-service/constants/error"
+	errField "field-service/constants/error/field"
 	"field-service/domain/dto"
 	"field-service/domain/models"
 	"field-service/repositories"
+	fieldScheduleService "field-service/services/field_schedule"
 	"fmt"
 	uuid2 "github.com/google/uuid"
+	"gorm.io/gorm"
 	"io"
 	"mime/multipart"
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 )
 
 type FieldService struct {
-	repository repositories.IRepositoryRegistry
+	repository    repositories.IRepositoryRegistry
+	fieldSchedule fieldScheduleService.IFieldScheduleLocker
 }
 
 type IFieldService interface {
@@ -32,8 +38,11 @@ type IFieldService interface {
 	Delete(context.Context, string) error
 }
 
-func NewFieldService(repository repositories.IRepositoryRegistry) IFieldService {
-	return &FieldService{repository}
+func NewFieldService(
+	repository repositories.IRepositoryRegistry,
+	fieldSchedule fieldScheduleService.IFieldScheduleLocker,
+) IFieldService {
+	return &FieldService{repository: repository, fieldSchedule: fieldSchedule}
 }
 
 func (f *FieldService) GetAllWithPagination(ctx context.Context, param *dto.FieldRequestParam) (*util.PaginationResult, error) {
@@ -49,6 +58,9 @@ func (f *FieldService) GetAllWithPagination(ctx context.Context, param *dto.Fiel
 			Code:         field.Code,
 			Name:         field.Name,
 			PricePerHour: field.PricePerHour,
+			BufferMinute: field.BufferMinute,
+			TimeSetID:    f.timeSetUUID(field.TimeSet),
+			ParentID:     f.parentUUID(field.Parent),
 			Images:       field.Images,
 			CreatedAt:    field.CreatedAt,
 			UpdatedAt:    field.UpdatedAt,
@@ -79,6 +91,9 @@ func (f *FieldService) GetAllWithoutPagination(ctx context.Context) ([]dto.Field
 			Code:         field.Code,
 			Name:         field.Name,
 			PricePerHour: field.PricePerHour,
+			BufferMinute: field.BufferMinute,
+			TimeSetID:    f.timeSetUUID(field.TimeSet),
+			ParentID:     f.parentUUID(field.Parent),
 			Images:       field.Images,
 		})
 	}
@@ -97,6 +112,9 @@ func (f *FieldService) GetByUUID(ctx context.Context, uuid string) (*dto.FieldRe
 		Code:         field.Code,
 		Name:         field.Name,
 		PricePerHour: field.PricePerHour,
+		BufferMinute: field.BufferMinute,
+		TimeSetID:    f.timeSetUUID(field.TimeSet),
+		ParentID:     f.parentUUID(field.Parent),
 		Images:       field.Images,
 		CreatedAt:    field.CreatedAt,
 		UpdatedAt:    field.UpdatedAt,
@@ -104,6 +122,165 @@ func (f *FieldService) GetByUUID(ctx context.Context, uuid string) (*dto.FieldRe
 
 	return fieldResult, nil
 }
+
+// findTimeSet mengambil time set pilihan, kosong berarti field memakai time set default.
+func (f *FieldService) findTimeSet(ctx context.Context, timeSetID string) (*models.TimeSet, error) {
+	if timeSetID == "" {
+		return nil, nil
+	}
+
+	return f.repository.GetTimeSet().FindByUUID(ctx, timeSetID)
+}
+
+func (f *FieldService) timeSetID(timeSet *models.TimeSet) *uint {
+	if timeSet == nil {
+		return nil
+	}
+
+	return &timeSet.ID
+}
+
+func (f *FieldService) timeSetUUID(timeSet *models.TimeSet) *uuid2.UUID {
+	if timeSet == nil {
+		return nil
+	}
+
+	return &timeSet.UUID
+}
+
+// resetUpcomingSchedules menghapus schedule mulai besok supaya job horizon membuatnya ulang dengan
+// time set baru, schedule hari ini tetap memakai time set lama. Perubahan ditolak jika masih ada
+// schedule mendatang yang sudah dipesan, sedang di-hold atau sedang maintenance.
+func (f *FieldService) resetUpcomingSchedules(ctx context.Context, tx *gorm.DB, field *models.Field) error {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	fieldSchedules, err := f.repository.GetFieldSchedule().FindAllByFieldIDFromDateForUpdate(
+		ctx,
+		tx,
+		field.ID,
+		today.AddDate(0, 0, 1).Format(time.DateOnly),
+	)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]uint, 0, len(fieldSchedules))
+	for _, fieldSchedule := range fieldSchedules {
+		isHoldActive := fieldSchedule.Status == constants.Held &&
+			fieldSchedule.HeldUntil != nil &&
+			fieldSchedule.HeldUntil.After(now)
+		if fieldSchedule.Status == constants.Booked || fieldSchedule.Status == constants.Maintenance || isHoldActive {
+			return errField.ErrFieldHasBookedSchedule
+		}
+
+		ids = append(ids, fieldSchedule.ID)
+	}
+
+	if len(ids) > 0 {
+		err = f.repository.GetFieldSchedule().DeleteByIDs(ctx, tx, ids)
+		if err != nil {
+			return err
+		}
+	}
+
+	return f.repository.GetField().UpdateScheduledUntil(ctx, tx, field.ID, today)
+}
+
+// findParent mengambil parent field pilihan, parent harus field lain yang tidak punya parent
+// dan field yang sudah punya child tidak boleh menjadi child.
+func (f *FieldService) findParent(ctx context.Context, parentID string, field *models.Field) (*models.Field, error) {
+	if parentID == "" {
+		return nil, nil
+	}
+
+	parent, err := f.repository.GetField().FindByUUID(ctx, parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if parent.ParentID != nil || (field != nil && parent.ID == field.ID) {
+		return nil, errField.ErrInvalidParentField
+	}
+
+	if field != nil {
+		total, err := f.repository.GetField().CountByParentID(ctx, field.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		if total > 0 {
+			return nil, errField.ErrFieldHasChildren
+		}
+	}
+
+	return parent, nil
+}
+
+func (f *FieldService) parentID(parent *models.Field) *uint {
+	if parent == nil {
+		return nil
+	}
+
+	return &parent.ID
+}
+
+// relinkFieldIDs mengembalikan field yang schedule-nya perlu disesuaikan ketika parent berubah,
+// yaitu field itu sendiri beserta parent lama dan parent baru. Hasilnya kosong jika parent tidak berubah.
+func (f *FieldService) relinkFieldIDs(field *models.Field, parent *models.Field) []uint {
+	if parent == nil && field.ParentID == nil {
+		return nil
+	}
+
+	if parent != nil && field.ParentID != nil && *field.ParentID == parent.ID {
+		return nil
+	}
+
+	fieldIDs := []uint{field.ID}
+	if field.ParentID != nil {
+		fieldIDs = append(fieldIDs, *field.ParentID)
+	}
+
+	if parent != nil {
+		fieldIDs = append(fieldIDs, parent.ID)
+	}
+
+	sort.Slice(fieldIDs, func(i, j int) bool {
+		return fieldIDs[i] < fieldIDs[j]
+	})
+
+	return fieldIDs
+}
+
+func (f *FieldService) parentUUID(parent *models.Field) *uuid2.UUID {
+	if parent == nil {
+		return nil
+	}
+
+	return &parent.UUID
+}
+
+// checkBuffer memastikan buffer lebih pendek dari setiap time slot di time set field. Buffer adalah menit
+// di akhir slot untuk persiapan lapangan, buffer mengurangi bookableTime dan tidak ikut dihargai.
+func (f *FieldService) checkBuffer(ctx context.Context, timeSet *models.TimeSet, bufferMinute int) error {
+	if bufferMinute == 0 {
+		return nil
+	}
+
+	times, err := f.repository.GetTime().FindAllByTimeSetID(ctx, f.timeSetID(timeSet))
+	if err != nil {
+		return err
+	}
+
+	for _, item := range times {
+		err = util.CheckBuffer(item.StartTime, item.EndTime, bufferMinute)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (f *FieldService) validateUpload(images []multipart.FileHeader) error {
 	if images == nil || len(images) == 0 {
 		return errConstant.ErrInvalidUploadFile
@@ -173,6 +350,21 @@ func (f *FieldService) uploadImage(ctx context.Context, images []multipart.FileH
 }
 
 func (f *FieldService) Create(ctx context.Context, request *dto.FieldRequest) (*dto.FieldResponse, error) {
+	timeSet, err := f.findTimeSet(ctx, request.TimeSetID)
+	if err != nil {
+		return nil, err
+	}
+
+	parent, err := f.findParent(ctx, request.ParentID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = f.checkBuffer(ctx, timeSet, request.BufferMinute)
+	if err != nil {
+		return nil, err
+	}
+
 	imageUrl, err := f.uploadImage(ctx, request.Images)
 	if err != nil {
 		return nil, err
@@ -183,6 +375,9 @@ func (f *FieldService) Create(ctx context.Context, request *dto.FieldRequest) (*
 		Name:         request.Name,
 		Images:       imageUrl,
 		PricePerHour: request.PricePerHour,
+		TimeSetID:    f.timeSetID(timeSet),
+		ParentID:     f.parentID(parent),
+		BufferMinute: request.BufferMinute,
 	})
 	if err != nil {
 		return nil, err
@@ -193,6 +388,9 @@ func (f *FieldService) Create(ctx context.Context, request *dto.FieldRequest) (*
 		Code:         field.Code,
 		Name:         field.Name,
 		PricePerHour: field.PricePerHour,
+		BufferMinute: field.BufferMinute,
+		TimeSetID:    f.timeSetUUID(timeSet),
+		ParentID:     f.parentUUID(parent),
 		Images:       field.Images,
 		CreatedAt:    field.CreatedAt,
 		UpdatedAt:    field.UpdatedAt,
@@ -207,6 +405,35 @@ func (f *FieldService) Update(ctx context.Context, uuid string, request *dto.Upd
 		return nil, err
 	}
 
+	// timeSetID yang tidak dikirim mempertahankan time set lama, string kosong kembali ke time set default
+	timeSet := field.TimeSet
+	if request.TimeSetID != nil {
+		timeSet, err = f.findTimeSet(ctx, *request.TimeSetID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// parentID yang tidak dikirim mempertahankan parent lama, string kosong melepas parent
+	parent := field.Parent
+	if request.ParentID != nil {
+		parent, err = f.findParent(ctx, *request.ParentID, field)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// bufferMinute yang tidak dikirim mempertahankan buffer lama, tetap dicek karena time set bisa berubah
+	bufferMinute := field.BufferMinute
+	if request.BufferMinute != nil {
+		bufferMinute = *request.BufferMinute
+	}
+
+	err = f.checkBuffer(ctx, timeSet, bufferMinute)
+	if err != nil {
+		return nil, err
+	}
+
 	var imageUrl []string
 	if request.Images != nil {
 		imageUrl, err = f.uploadImage(ctx, request.Images)
@@ -217,11 +444,51 @@ func (f *FieldService) Update(ctx context.Context, uuid string, request *dto.Upd
 		imageUrl = field.Images
 	}
 
-	fieldUpdated, err := f.repository.GetField().Update(ctx, uuid, &models.Field{
-		Code:         request.Code,
-		Name:         request.Name,
-		Images:       imageUrl,
-		PricePerHour: request.PricePerHour,
+	// grup field lama dan baru dikunci sebelum parent diubah supaya tidak balapan dengan pemesanan
+	fieldIDs := f.relinkFieldIDs(field, parent)
+	var fieldUpdated *models.Field
+	err = f.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		txErr := f.fieldSchedule.LockFieldGroups(ctx, tx, fieldIDs)
+		if txErr != nil {
+			return txErr
+		}
+
+		if !util.IsSameTimeSet(field.TimeSetID, f.timeSetID(timeSet)) {
+			txErr = f.resetUpcomingSchedules(ctx, tx, field)
+			if txErr != nil {
+				return txErr
+			}
+		}
+
+		fieldUpdated, txErr = f.repository.GetField().Update(ctx, tx, uuid, &models.Field{
+			Code:         request.Code,
+			Name:         request.Name,
+			Images:       imageUrl,
+			PricePerHour: request.PricePerHour,
+			TimeSetID:    f.timeSetID(timeSet),
+			ParentID:     f.parentID(parent),
+			BufferMinute: bufferMinute,
+		})
+		if txErr != nil {
+			return txErr
+		}
+
+		if len(fieldIDs) == 0 {
+			return nil
+		}
+
+		// schedule field lama dan baru disesuaikan dengan relasi baru, link ditolak jika
+		// kedua field sudah punya booking di jam yang beririsan
+		conflicts, txErr := f.fieldSchedule.RelinkFieldSchedules(ctx, tx, fieldIDs)
+		if txErr != nil {
+			return txErr
+		}
+
+		if len(conflicts) > 0 {
+			return errField.ErrLinkedFieldIsBooked
+		}
+
+		return nil
 	})
 	if err != nil {
 		return nil, err
@@ -234,6 +501,9 @@ func (f *FieldService) Update(ctx context.Context, uuid string, request *dto.Upd
 		Code:         fieldUpdated.Code,
 		Name:         fieldUpdated.Name,
 		PricePerHour: fieldUpdated.PricePerHour,
+		BufferMinute: fieldUpdated.BufferMinute,
+		TimeSetID:    f.timeSetUUID(timeSet),
+		ParentID:     f.parentUUID(parent),
 		Images:       fieldUpdated.Images,
 		CreatedAt:    fieldUpdated.CreatedAt,
 		UpdatedAt:    fieldUpdated.UpdatedAt,
@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"field-service/constants"
+	errFieldSchedule "field-service/constants/error/fieldSchedule"
+	"field-service/domain/dto"
+	"field-service/domain/models"
+	"field-service/repositories"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestUpdateStatusConcurrentBooking memastikan dua booking bersamaan untuk slot yang sama tidak bisa
+// sama-sama berhasil. Test ini butuh database postgres yang diisi lewat FIELD_SERVICE_TEST_DSN.
+func TestUpdateStatusConcurrentBooking(t *testing.T) {
+	dsn := os.Getenv("FIELD_SERVICE_TEST_DSN")
+	if dsn == "" {
+		t.Skip("FIELD_SERVICE_TEST_DSN is not set")
+	}
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{TranslateError: true})
+	if err != nil {
+		t.Fatalf("failed to connect database: %v", err)
+	}
+
+	err = db.AutoMigrate(
+		&models.TimeSet{},
+		&models.Field{},
+		&models.FieldSchedule{},
+		&models.Time{},
+		&models.FieldScheduleHistory{},
+		&models.PricingRule{},
+		&models.Waitlist{},
+	)
+	if err != nil {
+		t.Fatalf("failed to migrate database: %v", err)
+	}
+
+	scheduleTime := models.Time{UUID: uuid.New(), StartTime: "08:00:00", EndTime: "09:00:00"}
+	err = db.Create(&scheduleTime).Error
+	if err != nil {
+		t.Fatalf("failed to create time: %v", err)
+	}
+
+	field := models.Field{
+		UUID:         uuid.New(),
+		Code:         "TEST",
+		Name:         "Test Field",
+		PricePerHour: 100000,
+		Images:       pq.StringArray{},
+	}
+	err = db.Create(&field).Error
+	if err != nil {
+		t.Fatalf("failed to create field: %v", err)
+	}
+
+	fieldSchedule := models.FieldSchedule{
+		UUID:    uuid.New(),
+		FieldID: field.ID,
+		TimeID:  scheduleTime.ID,
+		Date:    time.Now().AddDate(0, 0, 1),
+		Status:  constants.Available,
+	}
+	err = db.Create(&fieldSchedule).Error
+	if err != nil {
+		t.Fatalf("failed to create field schedule: %v", err)
+	}
+
+	t.Cleanup(func() {
+		db.Unscoped().Delete(&fieldSchedule)
+		db.Unscoped().Delete(&field)
+		db.Unscoped().Delete(&scheduleTime)
+	})
+
+	service := NewFieldScheduleService(repositories.NewRepositoryRegistry(db))
+	errs := make([]error, 2)
+	var wg sync.WaitGroup
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = service.UpdateStatus(context.Background(), &dto.UpdateStatusFieldScheduleRequest{
+				FieldScheduleIDs: []string{fieldSchedule.UUID.String()},
+				UserID:           uuid.New().String(),
+			})
+		}(i)
+	}
+	wg.Wait()
+
+	succeeded := 0
+	for _, err := range errs {
+		if err == nil {
+			succeeded++
+			continue
+		}
+
+		if !errors.Is(err, errFieldSchedule.ErrFieldScheduleNotAvailable) {
+			t.Fatalf("expected error %v, got %v", errFieldSchedule.ErrFieldScheduleNotAvailable, err)
+		}
+	}
+
+	if succeeded != 1 {
+		t.Fatalf("expected exactly one booking to succeed, got %d", succeeded)
+	}
+
+	var booked models.FieldSchedule
+	err = db.First(&booked, fieldSchedule.ID).Error
+	if err != nil {
+		t.Fatalf("failed to find field schedule: %v", err)
+	}
+
+	if booked.Status != constants.Booked {
+		t.Fatalf("expected status %d, got %d", constants.Booked, booked.Status)
+	}
+}
@@ -2,14 +2,30 @@ package services
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	clientUser "field-service/clients/user"
+	"field-service/common/pricing"
 	"field-service/common/util"
+	"field-service/config"
 	"field-service/constants"
+	errBlackout "field-service/constants/error/blackout"
 	errFieldSchedule "field-service/constants/error/fieldSchedule"
+	errTime "field-service/constants/error/time"
+	errTimeSet "field-service/constants/error/timeSet"
 	"field-service/domain/dto"
 	"field-service/domain/models"
 	"field-service/repositories"
 	"fmt"
 	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"slices"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -20,35 +36,121 @@ type FieldScheduleService struct {
 type IFieldScheduleService interface {
 	GetAllWithPagination(context.Context, *dto.FieldScheduleRequestParam) (*util.PaginationResult, error)
 	GetAllByFieldIDAndDate(context.Context, string, string) ([]dto.FieldScheduleForBookingResponse, error)
+	GetCalendarByFieldID(context.Context, string, *dto.FieldScheduleCalendarRequestParam) ([]dto.FieldScheduleCalendarResponse, error)
+	Search(context.Context, *dto.FieldScheduleSearchRequestParam) ([]dto.FieldScheduleSearchResponse, error)
 	GetByUUID(context.Context, string) (*dto.FieldScheduleResponse, error)
-	GenerateScheduleForOneMonth(context.Context, *dto.GenerateFieldScheduleForOneMonthRequest) error
+	GenerateScheduleForOneMonth(context.Context, *dto.GenerateFieldScheduleForOneMonthRequest) (*dto.GenerateFieldScheduleResponse, error)
+	GenerateScheduleHorizon(context.Context) (*dto.GenerateScheduleHorizonResponse, error)
 	Create(context.Context, *dto.FieldScheduleRequest) error
 	Update(context.Context, string, *dto.UpdateFieldScheduleRequest) (*dto.FieldScheduleResponse, error)
-	UpdateStatus(ctx context.Context, request *dto.UpdateStatusFieldScheduleRequest) error
+	UpdateStatus(context.Context, *dto.UpdateStatusFieldScheduleRequest) (*dto.UpdateStatusFieldScheduleResponse, error)
+	Hold(context.Context, *dto.HoldFieldScheduleRequest) (*dto.HoldFieldScheduleResponse, error)
+	Quote(context.Context, *dto.QuoteFieldScheduleRequest) (*dto.QuoteFieldScheduleResponse, error)
+	ReleaseExpiredHold(context.Context) (int64, error)
+	Release(context.Context, *dto.ReleaseFieldScheduleRequest, string) error
+	StartMaintenance(context.Context, *dto.MaintenanceFieldScheduleRequest) (*dto.MaintenanceFieldScheduleResponse, error)
+	FinishMaintenance(context.Context, *dto.MaintenanceFieldScheduleRequest) (*dto.MaintenanceFieldScheduleResponse, error)
 	Delete(context.Context, string) error
 }
 
+// IFieldScheduleLocker adalah operasi schedule di dalam transaksi yang dipakai service lain
+// untuk mengunci, memblokir linked schedule dan menawarkan slot ke waitlist.
+type IFieldScheduleLocker interface {
+	SnapshotPrice(context.Context, *gorm.DB, []models.FieldSchedule) error
+	LockFieldGroups(context.Context, *gorm.DB, []uint) error
+	LockFieldSchedules(
+		context.Context,
+		*gorm.DB,
+		[]string,
+		...constants.FieldScheduleStatus,
+	) ([]models.FieldSchedule, []dto.FieldScheduleConflictResponse, error)
+	BlockLinkedSchedules(
+		context.Context,
+		*gorm.DB,
+		[]models.FieldSchedule,
+	) ([]models.FieldSchedule, []dto.FieldScheduleConflictResponse, error)
+	UnblockLinkedSchedules(context.Context, *gorm.DB, []models.FieldSchedule) ([]models.FieldSchedule, error)
+	RelinkFieldSchedules(context.Context, *gorm.DB, []uint) ([]dto.FieldScheduleConflictResponse, error)
+	CloseWaitlistOffer(context.Context, *gorm.DB, []uint, *uuid.UUID) error
+	OfferToWaitlist(context.Context, *gorm.DB, []models.FieldSchedule) error
+}
+
+// quotePayload adalah isi quote token yang ditandatangani dengan signature key service.
+type quotePayload struct {
+	FieldScheduleIDs []string `json:"fieldScheduleIDs"`
+	Subtotal         float64  `json:"subtotal"`
+	ExpiredAt        int64    `json:"expiredAt"`
+}
+
 func NewFieldScheduleService(repository repositories.IRepositoryRegistry) IFieldScheduleService {
 	return &FieldScheduleService{repository: repository}
 }
 
+func NewFieldScheduleLocker(repository repositories.IRepositoryRegistry) IFieldScheduleLocker {
+	return &FieldScheduleService{repository: repository}
+}
+
+// SnapshotPrice menyimpan harga yang berlaku saat ini ke schedule lama yang belum punya snapshot,
+// schedule yang sudah punya snapshot tetap memakai harga saat dibuat.
+func (f *FieldScheduleService) SnapshotPrice(ctx context.Context, tx *gorm.DB, fieldSchedules []models.FieldSchedule) error {
+	unpriced := make([]models.FieldSchedule, 0, len(fieldSchedules))
+	for _, fieldSchedule := range fieldSchedules {
+		if fieldSchedule.PricePerHour == nil || fieldSchedule.Price == nil {
+			unpriced = append(unpriced, fieldSchedule)
+		}
+	}
+
+	if len(unpriced) == 0 {
+		return nil
+	}
+
+	pricingRules, err := f.repository.GetPricingRule().FindAllByFieldIDs(ctx, util.FieldIDs(unpriced))
+	if err != nil {
+		return err
+	}
+
+	for i := range unpriced {
+		err = pricing.Snapshot(&unpriced[i], &unpriced[i].Field, &unpriced[i].Time, pricingRules)
+		if err != nil {
+			return err
+		}
+	}
+
+	return f.repository.GetFieldSchedule().UpdatePrices(ctx, tx, unpriced)
+}
+
 func (f *FieldScheduleService) GetAllWithPagination(ctx context.Context, param *dto.FieldScheduleRequestParam) (*util.PaginationResult, error) {
 	fieldSchedules, total, err := f.repository.GetFieldSchedule().FindAllWithPagination(ctx, param)
 	if err != nil {
 		return nil, err
 	}
 
+	// pricing rule diambil sekali untuk semua field di halaman ini
+	pricingRules, err := f.repository.GetPricingRule().FindAllByFieldIDs(ctx, util.FieldIDs(fieldSchedules))
+	if err != nil {
+		return nil, err
+	}
+
 	fieldScheduleResults := make([]dto.FieldScheduleResponse, 0, len(fieldSchedules))
 	for _, schedule := range fieldSchedules {
+		price, err := pricing.OfSchedule(&schedule, pricingRules)
+		if err != nil {
+			return nil, err
+		}
+
 		fieldScheduleResults = append(fieldScheduleResults, dto.FieldScheduleResponse{
-			UUID:         schedule.UUID,
-			FieldName:    schedule.Field.Name,
-			Date:         schedule.Date.Format("2006-01-02"),
-			PricePerHour: schedule.Field.PricePerHour,
-			Status:       schedule.Status.GetStatusString(),
-			Time:         fmt.Sprintf("%s - %s", schedule.Time.StartTime, schedule.Time.EndTime),
-			CreatedAt:    schedule.CreatedAt,
-			UpdatedAt:    schedule.UpdatedAt,
+			UUID:           schedule.UUID,
+			FieldName:      schedule.Field.Name,
+			Date:           schedule.Date.Format("2006-01-02"),
+			PricePerHour:   price.PricePerHour,
+			DurationMinute: price.DurationMinute,
+			Price:          price.Price,
+			PriceFormatted: util.RupiahFormat(&price.Price),
+			PricingRule:    price.PricingRuleName(),
+			Status:         schedule.Status.GetStatusString(),
+			Time:           fmt.Sprintf("%s - %s", schedule.Time.StartTime, schedule.Time.EndTime),
+			CreatedAt:      schedule.CreatedAt,
+			UpdatedAt:      schedule.UpdatedAt,
 		})
 	}
 
@@ -105,204 +207,2279 @@ func (f *FieldScheduleService) GetAllByFieldIDAndDate(
 		return nil, err
 	}
 
+	pricingRules, err := f.repository.GetPricingRule().FindAllByFieldIDs(ctx, []uint{field.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
 	fieldScheduleResults := make([]dto.FieldScheduleForBookingResponse, 0, len(fieldSchedules))
 	for _, fieldSchedule := range fieldSchedules {
-		pricePerHour := float64(fieldSchedule.Field.PricePerHour)
-		startTime, _ := time.Parse("15:04:05", fieldSchedule.Time.StartTime)
-		endTime, _ := time.Parse("15:04:05", fieldSchedule.Time.EndTime)
-		fieldScheduleResults = append(fieldScheduleResults, dto.FieldScheduleForBookingResponse{
-			UUID:         fieldSchedule.UUID,
-			PricePerHour: util.RupiahFormat(&pricePerHour),
-			Date:         f.convertMonthName(fieldSchedule.Date.Format("2006-01-02")),
-			Status:       fieldSchedule.Status.GetStatusString(),
-			Time:         fmt.Sprintf("%s - %s", startTime.Format("15:04"), endTime.Format("15:04")),
-		})
+		// schedule dengan jam yang tidak valid dilewati supaya tidak menggagalkan seluruh daftar
+		price, err := pricing.OfSchedule(&fieldSchedule, pricingRules)
+		if err != nil {
+			logrus.Warnf("skip field schedule %s with invalid time: %v", fieldSchedule.UUID, err)
+			continue
+		}
+
+		// hold yang sudah lewat tapi belum disapu job tetap ditampilkan available
+		status := fieldSchedule.Status
+		if util.IsHoldExpired(fieldSchedule, now) {
+			status = constants.Available
+		}
+
+		fieldScheduleResult, err := f.toBookingResponse(&fieldSchedule, price, status)
+		if err != nil {
+			logrus.Warnf("skip field schedule %s with invalid time: %v", fieldSchedule.UUID, err)
+			continue
+		}
+		fieldScheduleResults = append(fieldScheduleResults, *fieldScheduleResult)
 	}
 
 	return fieldScheduleResults, nil
 }
 
-func (f *FieldScheduleService) GetByUUID(ctx context.Context, uuid string) (*dto.FieldScheduleResponse, error) {
-	fieldSchedule, err := f.repository.GetFieldSchedule().FindByUUID(ctx, uuid)
+func (f *FieldScheduleService) toBookingResponse(
+	fieldSchedule *models.FieldSchedule,
+	price *pricing.SchedulePrice,
+	status constants.FieldScheduleStatus,
+) (*dto.FieldScheduleForBookingResponse, error) {
+	startTime, err := util.ParseTime(fieldSchedule.Time.StartTime)
 	if err != nil {
 		return nil, err
 	}
 
-	response := dto.FieldScheduleResponse{
-		UUID:         fieldSchedule.UUID,
-		FieldName:    fieldSchedule.Field.Name,
-		PricePerHour: fieldSchedule.Field.PricePerHour,
-		Date:         fieldSchedule.Date.Format(time.DateOnly),
-		Status:       fieldSchedule.Status.GetStatusString(),
-		Time:         fmt.Sprintf("%s - %s", fieldSchedule.Time.StartTime, fieldSchedule.Time.EndTime),
-		CreatedAt:    fieldSchedule.CreatedAt,
-		UpdatedAt:    fieldSchedule.UpdatedAt,
+	endTime, err := util.ParseTime(fieldSchedule.Time.EndTime)
+	if err != nil {
+		return nil, err
 	}
 
-	return &response, nil
+	// buffer memotong jam main di akhir slot, harga slot hanya dihitung dari jam yang bisa dipesan
+	bookableEndTime, err := util.BookableEndTime(fieldSchedule.Time.EndTime, fieldSchedule.Field.BufferMinute)
+	if err != nil {
+		return nil, err
+	}
+
+	pricePerHour := float64(price.PricePerHour)
+	return &dto.FieldScheduleForBookingResponse{
+		UUID:           fieldSchedule.UUID,
+		PricePerHour:   util.RupiahFormat(&pricePerHour),
+		DurationMinute: price.DurationMinute,
+		Price:          price.Price,
+		PriceFormatted: util.RupiahFormat(&price.Price),
+		PricingRule:    price.PricingRuleName(),
+		Date:           f.convertMonthName(fieldSchedule.Date.Format("2006-01-02")),
+		Status:         status.GetStatusString(),
+		Time:           fmt.Sprintf("%s - %s", startTime.Format("15:04"), endTime.Format("15:04")),
+		BookableTime:   fmt.Sprintf("%s - %s", startTime.Format("15:04"), bookableEndTime.Format("15:04")),
+		BufferMinute:   fieldSchedule.Field.BufferMinute,
+	}, nil
 }
 
-func (f *FieldScheduleService) GenerateScheduleForOneMonth(ctx context.Context, request *dto.GenerateFieldScheduleForOneMonthRequest) error {
-	field, err := f.repository.GetField().FindByUUID(ctx, request.FieldID)
-	if err != nil {
-		return err
+// consecutiveRuns mengelompokkan schedule satu field yang sudah terurut berdasarkan jam mulai
+// menjadi rangkaian tanpa jeda, lalu membuang rangkaian yang durasinya kurang dari minDurationMinute.
+func (f *FieldScheduleService) consecutiveRuns(
+	fieldSchedules []models.FieldSchedule,
+	prices []*pricing.SchedulePrice,
+	minDurationMinute int,
+) ([]int, error) {
+	indexes := make([]int, 0, len(fieldSchedules))
+	run := make([]int, 0)
+	runDuration := 0
+	previousEndTime := ""
+	flush := func() {
+		if len(run) > 0 && runDuration >= minDurationMinute {
+			indexes = append(indexes, run...)
+		}
+		run = run[:0]
+		runDuration = 0
+	}
+
+	for i, fieldSchedule := range fieldSchedules {
+		startTime, err := util.NormalizeTime(fieldSchedule.Time.StartTime)
+		if err != nil {
+			return nil, err
+		}
+
+		endTime, err := util.NormalizeTime(fieldSchedule.Time.EndTime)
+		if err != nil {
+			return nil, err
+		}
+
+		if startTime != previousEndTime {
+			flush()
+		}
+
+		run = append(run, i)
+		runDuration += prices[i].DurationMinute
+		previousEndTime = endTime
 	}
+	flush()
+
+	return indexes, nil
+}
 
-	times, err := f.repository.GetTime().FindAll(ctx)
+func (f *FieldScheduleService) Search(
+	ctx context.Context,
+	request *dto.FieldScheduleSearchRequestParam,
+) ([]dto.FieldScheduleSearchResponse, error) {
+	date, err := util.ParseDate(request.Date)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	numberOfDays := 30
-	fieldSchedules := make([]models.FieldSchedule, 0, numberOfDays)
-	now := time.Now().Add(time.Duration(1) * 24 * time.Hour)
-	for i := 0; i < numberOfDays; i++ {
-		currentDate := now.AddDate(0, 0, i)
-		for _, timeItem := range times {
-			schedule, err := f.repository.GetFieldSchedule().FindByDateAndTimeID(
-				ctx,
-				currentDate.Format(time.DateOnly),
-				int(timeItem.ID),
-				int(field.ID),
-			)
-			if err != nil {
-				return err
-			}
-			if schedule != nil {
-				return errFieldSchedule.ErrFieldScheduleIsExist
-			}
+	var startTime, endTime string
+	if request.StartTime != "" {
+		startTime, err = util.NormalizeTime(request.StartTime)
+		if err != nil {
+			return nil, err
+		}
+	}
 
-			fieldSchedules = append(fieldSchedules, models.FieldSchedule{
-				UUID:    uuid.New(),
-				FieldID: field.ID,
-				TimeID:  timeItem.ID,
-				Date:    currentDate,
-				Status:  constants.Available,
-			})
+	if request.EndTime != "" {
+		endTime, err = util.NormalizeTime(request.EndTime)
+		if err != nil {
+			return nil, err
 		}
 	}
-	err = f.repository.GetFieldSchedule().Create(ctx, fieldSchedules)
-	if err != nil {
-		return err
+
+	if startTime != "" && endTime != "" && endTime <= startTime {
+		return nil, errTime.ErrInvalidTimeRange
 	}
 
-	return nil
-}
+	now := time.Now()
+	fieldSchedules, err := f.repository.GetFieldSchedule().FindAllAvailableByDate(
+		ctx,
+		date.Format(time.DateOnly),
+		startTime,
+		endTime,
+		request.Name,
+		now,
+	)
+	if err != nil {
+		return nil, err
+	}
 
-func (f *FieldScheduleService) Create(ctx context.Context, request *dto.FieldScheduleRequest) error {
-	field, err := f.repository.GetField().FindByUUID(ctx, request.FieldID)
+	pricingRules, err := f.repository.GetPricingRule().FindAllByFieldIDs(ctx, util.FieldIDs(fieldSchedules))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	fieldSchedules := make([]models.FieldSchedule, 0, len(request.TimeIDs))
-	dateParsed, _ := time.Parse(time.DateOnly, request.Date)
-	for _, timeID := range request.TimeIDs {
-		scheduleTime, err := f.repository.GetTime().FindByUUID(ctx, timeID)
+	// schedule sudah terurut per field, kumpulkan schedule yang harganya masuk batas per field
+	groups := make([][]models.FieldSchedule, 0)
+	groupPrices := make([][]*pricing.SchedulePrice, 0)
+	for _, fieldSchedule := range fieldSchedules {
+		startAt, err := util.ScheduleStartAt(&fieldSchedule)
 		if err != nil {
-			return err
+			logrus.Warnf("skip field schedule %s with invalid time: %v", fieldSchedule.UUID, err)
+			continue
 		}
 
-		schedule, err := f.repository.GetFieldSchedule().FindByDateAndTimeID(ctx, request.Date, int(scheduleTime.ID), int(field.ID))
+		// slot hari ini yang sudah dimulai tidak bisa dipesan lagi
+		if startAt.Before(now) {
+			continue
+		}
+
+		price, err := pricing.OfSchedule(&fieldSchedule, pricingRules)
 		if err != nil {
-			return err
+			logrus.Warnf("skip field schedule %s with invalid time: %v", fieldSchedule.UUID, err)
+			continue
 		}
 
-		if schedule != nil {
-			return errFieldSchedule.ErrFieldScheduleIsExist
+		if request.MaxPricePerHour > 0 && price.PricePerHour > request.MaxPricePerHour {
+			continue
 		}
 
-		fieldSchedules = append(fieldSchedules, models.FieldSchedule{
-			UUID:    uuid.New(),
-			FieldID: field.ID,
-			TimeID:  scheduleTime.ID,
-			Date:    dateParsed,
-			Status:  constants.Available,
+		last := len(groups) - 1
+		if last < 0 || groups[last][0].FieldID != fieldSchedule.FieldID {
+			groups = append(groups, make([]models.FieldSchedule, 0))
+			groupPrices = append(groupPrices, make([]*pricing.SchedulePrice, 0))
+			last++
+		}
+		groups[last] = append(groups[last], fieldSchedule)
+		groupPrices[last] = append(groupPrices[last], price)
+	}
+
+	results := make([]dto.FieldScheduleSearchResponse, 0, len(groups))
+	for i, group := range groups {
+		indexes, err := f.consecutiveRuns(group, groupPrices[i], request.MinDurationMinute)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(indexes) == 0 {
+			continue
+		}
+
+		field := group[0].Field
+		slots := make([]dto.FieldScheduleForBookingResponse, 0, len(indexes))
+		// harga per jam field dilaporkan dari slot termurah hasil pricing rule, bukan harga dasar field
+		pricePerHour := groupPrices[i][indexes[0]].PricePerHour
+		for _, index := range indexes {
+			slot, err := f.toBookingResponse(&group[index], groupPrices[i][index], constants.Available)
+			if err != nil {
+				return nil, err
+			}
+			slots = append(slots, *slot)
+			pricePerHour = min(pricePerHour, groupPrices[i][index].PricePerHour)
+		}
+
+		results = append(results, dto.FieldScheduleSearchResponse{
+			FieldID:      field.UUID,
+			FieldCode:    field.Code,
+			FieldName:    field.Name,
+			PricePerHour: pricePerHour,
+			Images:       field.Images,
+			Slots:        slots,
 		})
 	}
 
-	err = f.repository.GetFieldSchedule().Create(ctx, fieldSchedules)
+	return results, nil
+}
+
+// calendarDateRange mengembalikan tanggal awal dan akhir bulan jika month diisi,
+// selain itu memakai startDate dan endDate dari request.
+func (f *FieldScheduleService) calendarDateRange(
+	request *dto.FieldScheduleCalendarRequestParam,
+) (time.Time, time.Time, error) {
+	if request.Month != "" {
+		month, err := time.ParseInLocation("2006-01", request.Month, time.Local)
+		if err != nil {
+			return time.Time{}, time.Time{}, errFieldSchedule.ErrInvalidMonth
+		}
+
+		return month, month.AddDate(0, 1, -1), nil
+	}
+
+	startDate, err := util.ParseDate(request.StartDate)
 	if err != nil {
-		return err
+		return time.Time{}, time.Time{}, err
 	}
-	return nil
+
+	endDate, err := util.ParseDate(request.EndDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	if endDate.Before(startDate) {
+		return time.Time{}, time.Time{}, errFieldSchedule.ErrInvalidDateRange
+	}
+
+	if endDate.After(startDate.AddDate(0, 0, constants.MaxCalendarNumberOfDays-1)) {
+		return time.Time{}, time.Time{}, errFieldSchedule.ErrDateRangeTooLong
+	}
+
+	return startDate, endDate, nil
 }
 
-func (f *FieldScheduleService) Update(ctx context.Context, uuid string, request *dto.UpdateFieldScheduleRequest) (*dto.FieldScheduleResponse, error) {
-	fieldSchedule, err := f.repository.GetFieldSchedule().FindByUUID(ctx, uuid)
+func (f *FieldScheduleService) GetCalendarByFieldID(
+	ctx context.Context,
+	uuid string,
+	request *dto.FieldScheduleCalendarRequestParam,
+) ([]dto.FieldScheduleCalendarResponse, error) {
+	field, err := f.repository.GetField().FindByUUID(ctx, uuid)
 	if err != nil {
 		return nil, err
 	}
 
-	scheduleTime, err := f.repository.GetTime().FindByUUID(ctx, request.TimeID)
+	startDate, endDate, err := f.calendarDateRange(request)
 	if err != nil {
 		return nil, err
 	}
 
-	isTimeExist, err := f.repository.GetFieldSchedule().FindByDateAndTimeID(ctx, request.Date, int(scheduleTime.ID), int(fieldSchedule.FieldID))
+	summaries, err := f.repository.GetFieldSchedule().SummarizeByFieldIDAndDateRange(
+		ctx,
+		int(field.ID),
+		startDate.Format(time.DateOnly),
+		endDate.Format(time.DateOnly),
+		time.Now(),
+	)
 	if err != nil {
 		return nil, err
 	}
 
-	if isTimeExist != nil && request.Date != fieldSchedule.Date.Format(time.DateOnly) {
-		checkDate, err := f.repository.GetFieldSchedule().FindByDateAndTimeID(ctx, request.Date, int(scheduleTime.ID), int(fieldSchedule.FieldID))
-		if err != nil {
-			return nil, err
-		}
+	blackouts, err := f.repository.GetBlackout().FindAllByFieldIDAndDateRange(
+		ctx,
+		int(field.ID),
+		startDate.Format(time.DateOnly),
+		endDate.Format(time.DateOnly),
+	)
+	if err != nil {
+		return nil, err
+	}
 
-		if checkDate != nil {
-			return nil, errFieldSchedule.ErrFieldScheduleIsExist
-		}
+	summaryByDate := make(map[string]models.FieldScheduleSummary, len(summaries))
+	for _, summary := range summaries {
+		summaryByDate[summary.Date.Format(time.DateOnly)] = summary
 	}
 
-	dateParsed, _ := time.Parse(time.DateOnly, request.Date)
-	fieldScheduleUpdated, err := f.repository.GetFieldSchedule().Update(ctx, uuid, &models.FieldSchedule{
-		Date:   dateParsed,
-		TimeID: scheduleTime.ID,
-	})
+	// tanggal tanpa schedule tetap dikembalikan supaya kalender selalu lengkap
+	calendar := make([]dto.FieldScheduleCalendarResponse, 0)
+	for currentDate := startDate; !currentDate.After(endDate); currentDate = currentDate.AddDate(0, 0, 1) {
+		summary := summaryByDate[currentDate.Format(time.DateOnly)]
+		// penuh hanya jika slot habis karena di-booking atau di-hold, bukan karena semuanya diblokir
+		fullyBooked := summary.Available == 0 && summary.Booked+summary.Held > 0
+		calendar = append(calendar, dto.FieldScheduleCalendarResponse{
+			Date:        currentDate.Format(time.DateOnly),
+			Total:       summary.Total,
+			Available:   summary.Available,
+			Held:        summary.Held,
+			Booked:      summary.Booked,
+			Blocked:     summary.Blocked,
+			Blackout:    util.IsBlackout(currentDate, blackouts),
+			FullyBooked: fullyBooked,
+		})
+	}
+
+	return calendar, nil
+}
+
+func (f *FieldScheduleService) GetByUUID(ctx context.Context, uuid string) (*dto.FieldScheduleResponse, error) {
+	fieldSchedule, err := f.repository.GetFieldSchedule().FindByUUID(ctx, uuid)
 	if err != nil {
 		return nil, err
 	}
 
-	response := &dto.FieldScheduleResponse{
-		UUID:         fieldScheduleUpdated.UUID,
-		FieldName:    fieldScheduleUpdated.Field.Name,
-		Date:         fieldScheduleUpdated.Date.Format(time.DateOnly),
-		PricePerHour: fieldScheduleUpdated.Field.PricePerHour,
-		Status:       fieldScheduleUpdated.Status.GetStatusString(),
-		Time:         fmt.Sprintf("%s - %s", scheduleTime.StartTime, scheduleTime.EndTime),
-		CreatedAt:    fieldScheduleUpdated.CreatedAt,
-		UpdatedAt:    fieldScheduleUpdated.UpdatedAt,
+	pricingRules, err := f.repository.GetPricingRule().FindAllByFieldIDs(ctx, []uint{fieldSchedule.FieldID})
+	if err != nil {
+		return nil, err
 	}
 
-	return response, nil
+	price, err := pricing.OfSchedule(fieldSchedule, pricingRules)
+	if err != nil {
+		return nil, err
+	}
+
+	response := dto.FieldScheduleResponse{
+		UUID:           fieldSchedule.UUID,
+		FieldName:      fieldSchedule.Field.Name,
+		PricePerHour:   price.PricePerHour,
+		DurationMinute: price.DurationMinute,
+		Price:          price.Price,
+		PriceFormatted: util.RupiahFormat(&price.Price),
+		PricingRule:    price.PricingRuleName(),
+		Date:           fieldSchedule.Date.Format(time.DateOnly),
+		Status:         fieldSchedule.Status.GetStatusString(),
+		Time:           fmt.Sprintf("%s - %s", fieldSchedule.Time.StartTime, fieldSchedule.Time.EndTime),
+		CreatedAt:      fieldSchedule.CreatedAt,
+		UpdatedAt:      fieldSchedule.UpdatedAt,
+	}
+
+	return &response, nil
 }
 
-func (f *FieldScheduleService) UpdateStatus(ctx context.Context, request *dto.UpdateStatusFieldScheduleRequest) error {
-	for _, item := range request.FieldScheduleIDs {
-		_, err := f.repository.GetFieldSchedule().FindByUUID(ctx, item)
+func (f *FieldScheduleService) generateDateRange(
+	request *dto.GenerateFieldScheduleForOneMonthRequest,
+) (time.Time, time.Time, error) {
+	// default mulai dari besok
+	now := time.Now()
+	startDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local).AddDate(0, 0, 1)
+	if request.StartDate != "" {
+		dateParsed, err := util.ParseDate(request.StartDate)
 		if err != nil {
-			return err
+			return time.Time{}, time.Time{}, err
 		}
+		startDate = dateParsed
+	}
 
-		err = f.repository.GetFieldSchedule().UpdateStatus(ctx, constants.Booked, item)
+	var endDate time.Time
+	if request.EndDate != "" {
+		dateParsed, err := util.ParseDate(request.EndDate)
 		if err != nil {
-			return err
+			return time.Time{}, time.Time{}, err
+		}
+
+		if dateParsed.Before(startDate) {
+			return time.Time{}, time.Time{}, errFieldSchedule.ErrInvalidDateRange
+		}
+		endDate = dateParsed
+	} else {
+		numberOfDays := request.NumberOfDays
+		if numberOfDays <= 0 {
+			numberOfDays = constants.DefaultGenerateScheduleNumberOfDays
 		}
+		endDate = startDate.AddDate(0, 0, numberOfDays-1)
 	}
-	return nil
+
+	if endDate.After(startDate.AddDate(0, 0, constants.MaxGenerateScheduleNumberOfDays-1)) {
+		return time.Time{}, time.Time{}, errFieldSchedule.ErrDateRangeTooLong
+	}
+
+	return startDate, endDate, nil
 }
 
-func (f *FieldScheduleService) Delete(ctx context.Context, uuid string) error {
-	_, err := f.repository.GetFieldSchedule().FindByUUID(ctx, uuid)
+// generateSchedules membuat schedule available untuk setiap hari di antara startDate dan endDate.
+// Pada mode skip, schedule yang sudah ada dilewati dan dihitung sebagai skipped.
+func (f *FieldScheduleService) generateSchedules(
+	ctx context.Context,
+	field *models.Field,
+	startDate, endDate time.Time,
+	mode string,
+) (*dto.GenerateFieldScheduleResponse, error) {
+	times, err := f.repository.GetTime().FindAllByTimeSetID(ctx, field.TimeSetID)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	for _, timeItem := range times {
+		err = util.CheckBuffer(timeItem.StartTime, timeItem.EndTime, field.BufferMinute)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	err = f.repository.GetFieldSchedule().Delete(ctx, uuid)
+	existingSchedules, err := f.repository.GetFieldSchedule().FindAllByFieldIDAndDateRange(
+		ctx,
+		int(field.ID),
+		startDate.Format(time.DateOnly),
+		endDate.Format(time.DateOnly),
+	)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	scheduleRules, err := f.repository.GetScheduleRule().FindAllByFieldIDAndDateRange(
+		ctx,
+		int(field.ID),
+		startDate.Format(time.DateOnly),
+		endDate.Format(time.DateOnly),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	blackouts, err := f.repository.GetBlackout().FindAllByFieldIDAndDateRange(
+		ctx,
+		int(field.ID),
+		startDate.Format(time.DateOnly),
+		endDate.Format(time.DateOnly),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	pricingRules, err := f.repository.GetPricingRule().FindAllByFieldIDs(ctx, []uint{field.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	standingReservations, err := f.repository.GetStandingReservation().FindAllActiveByFieldIDAndDateRange(
+		ctx,
+		field.ID,
+		startDate.Format(time.DateOnly),
+		endDate.Format(time.DateOnly),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]bool, len(existingSchedules))
+	for _, schedule := range existingSchedules {
+		existing[util.ScheduleKey(schedule.Date, schedule.TimeID)] = true
+	}
+
+	skipped := 0
+	closedDates := make([]string, 0)
+	fieldSchedules := make([]models.FieldSchedule, 0)
+	for currentDate := startDate; !currentDate.After(endDate); currentDate = currentDate.AddDate(0, 0, 1) {
+		if util.IsBlackout(currentDate, blackouts) {
+			closedDates = append(closedDates, currentDate.Format(time.DateOnly))
+			continue
+		}
+
+		for _, timeItem := range util.TimesForDate(currentDate, times, scheduleRules) {
+			if existing[util.ScheduleKey(currentDate, timeItem.ID)] {
+				if mode != constants.GenerateScheduleSkipMode {
+					return nil, errFieldSchedule.ErrFieldScheduleIsExist
+				}
+
+				skipped++
+				continue
+			}
+
+			fieldSchedule := models.FieldSchedule{
+				UUID:    uuid.New(),
+				FieldID: field.ID,
+				TimeID:  timeItem.ID,
+				Date:    currentDate,
+				Status:  constants.Available,
+			}
+			f.applyStandingReservation(&fieldSchedule, standingReservations)
+			err = pricing.Snapshot(&fieldSchedule, field, &timeItem, pricingRules)
+			if err != nil {
+				return nil, err
+			}
+			fieldSchedules = append(fieldSchedules, fieldSchedule)
+		}
+	}
+
+	created := len(fieldSchedules)
+	if created > 0 {
+		err = f.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+			err := f.LockFieldGroups(ctx, tx, []uint{field.ID})
+			if err != nil {
+				return err
+			}
+
+			if mode == constants.GenerateScheduleSkipMode {
+				total, err := f.repository.GetFieldSchedule().CreateSkipExisting(ctx, tx, fieldSchedules)
+				if err != nil {
+					return err
+				}
+
+				// schedule yang dibuat oleh request lain di saat bersamaan juga dihitung sebagai skipped
+				skipped += created - int(total)
+				created = int(total)
+			} else {
+				err := f.repository.GetFieldSchedule().CreateWithTx(ctx, tx, fieldSchedules)
+				if err != nil {
+					return err
+				}
+			}
+
+			return f.blockGeneratedSchedules(ctx, tx, fieldSchedules)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = f.advanceScheduledUntil(ctx, field, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.GenerateFieldScheduleResponse{
+		StartDate:   startDate.Format(time.DateOnly),
+		EndDate:     endDate.Format(time.DateOnly),
+		Created:     created,
+		Skipped:     skipped,
+		ClosedDates: closedDates,
+	}
+
+	return response, nil
+}
+
+// scheduledUntil mengembalikan tanggal terakhir yang sudah dibuat untuk field, field lama yang belum
+// punya scheduled_until memakai tanggal schedule terakhirnya.
+func (f *FieldScheduleService) scheduledUntil(ctx context.Context, field *models.Field) (*time.Time, error) {
+	if field.ScheduledUntil != nil {
+		return field.ScheduledUntil, nil
+	}
+
+	return f.repository.GetFieldSchedule().FindLastDateByFieldID(ctx, field.ID)
+}
+
+// advanceScheduledUntil memajukan scheduled_until hanya jika rentang yang dibuat menyambung dengan
+// rentang sebelumnya, supaya job horizon tidak melompati tanggal yang belum pernah dibuat.
+func (f *FieldScheduleService) advanceScheduledUntil(ctx context.Context, field *models.Field, startDate, endDate time.Time) error {
+	scheduledUntil, err := f.scheduledUntil(ctx, field)
+	if err != nil {
+		return err
+	}
+
+	nextDate := time.Now().AddDate(0, 0, 1)
+	if scheduledUntil != nil && scheduledUntil.Format(time.DateOnly) >= nextDate.Format(time.DateOnly) {
+		nextDate = scheduledUntil.AddDate(0, 0, 1)
+	}
+
+	if startDate.Format(time.DateOnly) > nextDate.Format(time.DateOnly) ||
+		(scheduledUntil != nil && endDate.Format(time.DateOnly) <= scheduledUntil.Format(time.DateOnly)) {
+		return nil
+	}
+
+	field.ScheduledUntil = &endDate
+	return f.repository.GetField().UpdateScheduledUntil(ctx, f.repository.GetTx(), field.ID, endDate)
+}
+
+func (f *FieldScheduleService) GenerateScheduleForOneMonth(
+	ctx context.Context,
+	request *dto.GenerateFieldScheduleForOneMonthRequest,
+) (*dto.GenerateFieldScheduleResponse, error) {
+	field, err := f.repository.GetField().FindByUUID(ctx, request.FieldID)
+	if err != nil {
+		return nil, err
+	}
+
+	startDate, endDate, err := f.generateDateRange(request)
+	if err != nil {
+		return nil, err
+	}
+
+	mode := request.Mode
+	if mode == "" {
+		mode = constants.GenerateScheduleStrictMode
+	}
+
+	return f.generateSchedules(ctx, field, startDate, endDate, mode)
+}
+
+// GenerateScheduleHorizon memastikan setiap field punya schedule available sampai N hari ke depan.
+// Advisory lock dipakai supaya hanya satu replica yang menjalankan proses ini dalam satu waktu.
+func (f *FieldScheduleService) GenerateScheduleHorizon(ctx context.Context) (*dto.GenerateScheduleHorizonResponse, error) {
+	numberOfDays := config.Config.ScheduleHorizonDay
+	if numberOfDays <= 0 {
+		numberOfDays = constants.DefaultScheduleHorizonDay
+	}
+
+	response := &dto.GenerateScheduleHorizonResponse{}
+	err := f.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		locked, err := f.repository.GetFieldSchedule().TryAdvisoryLock(ctx, tx, constants.ScheduleHorizonLockKey)
+		if err != nil {
+			return err
+		}
+
+		if !locked {
+			return nil
+		}
+		response.Acquired = true
+
+		fields, err := f.repository.GetField().FindAllWithoutPagination(ctx)
+		if err != nil {
+			return err
+		}
+
+		startDate, endDate, err := f.generateDateRange(&dto.GenerateFieldScheduleForOneMonthRequest{
+			NumberOfDays: numberOfDays,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, field := range fields {
+			result := dto.GenerateFieldScheduleHorizonResponse{
+				FieldID:   field.UUID,
+				FieldName: field.Name,
+			}
+
+			// hanya tanggal setelah horizon terakhir yang dibuat, schedule yang sengaja dihapus admin tidak dibuat ulang
+			scheduledUntil, err := f.scheduledUntil(ctx, &field)
+			if err != nil {
+				result.Error = err
+				response.Fields = append(response.Fields, result)
+				continue
+			}
+
+			fieldStartDate := startDate
+			if scheduledUntil != nil && scheduledUntil.Format(time.DateOnly) >= startDate.Format(time.DateOnly) {
+				fieldStartDate, err = util.ParseDate(scheduledUntil.AddDate(0, 0, 1).Format(time.DateOnly))
+				if err != nil {
+					result.Error = err
+					response.Fields = append(response.Fields, result)
+					continue
+				}
+			}
+
+			if fieldStartDate.After(endDate) {
+				response.Fields = append(response.Fields, result)
+				continue
+			}
+
+			generated, err := f.generateSchedules(ctx, &field, fieldStartDate, endDate, constants.GenerateScheduleSkipMode)
+			if err != nil {
+				result.Error = err
+			} else {
+				result.Created = generated.Created
+				result.Skipped = generated.Skipped
+			}
+
+			response.Fields = append(response.Fields, result)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (f *FieldScheduleService) Create(ctx context.Context, request *dto.FieldScheduleRequest) error {
+	field, err := f.repository.GetField().FindByUUID(ctx, request.FieldID)
+	if err != nil {
+		return err
+	}
+
+	dateParsed, err := util.ParseDate(request.Date)
+	if err != nil {
+		return err
+	}
+
+	blackouts, err := f.repository.GetBlackout().FindAllByFieldIDAndDateRange(ctx, int(field.ID), request.Date, request.Date)
+	if err != nil {
+		return err
+	}
+
+	if util.IsBlackout(dateParsed, blackouts) {
+		return errBlackout.ErrDateIsBlackout
+	}
+
+	pricingRules, err := f.repository.GetPricingRule().FindAllByFieldIDs(ctx, []uint{field.ID})
+	if err != nil {
+		return err
+	}
+
+	standingReservations, err := f.repository.GetStandingReservation().FindAllActiveByFieldIDAndDateRange(
+		ctx,
+		field.ID,
+		request.Date,
+		request.Date,
+	)
+	if err != nil {
+		return err
+	}
+
+	fieldSchedules := make([]models.FieldSchedule, 0, len(request.TimeIDs))
+	for _, timeID := range request.TimeIDs {
+		scheduleTime, err := f.repository.GetTime().FindByUUID(ctx, timeID)
+		if err != nil {
+			return err
+		}
+
+		if !util.IsSameTimeSet(scheduleTime.TimeSetID, field.TimeSetID) {
+			return errTimeSet.ErrTimeNotInTimeSet
+		}
+
+		err = util.CheckBuffer(scheduleTime.StartTime, scheduleTime.EndTime, field.BufferMinute)
+		if err != nil {
+			return err
+		}
+
+		schedule, err := f.repository.GetFieldSchedule().FindByDateAndTimeID(ctx, request.Date, int(scheduleTime.ID), int(field.ID))
+		if err != nil {
+			return err
+		}
+
+		if schedule != nil {
+			return errFieldSchedule.ErrFieldScheduleIsExist
+		}
+
+		fieldSchedule := models.FieldSchedule{
+			UUID:    uuid.New(),
+			FieldID: field.ID,
+			TimeID:  scheduleTime.ID,
+			Date:    dateParsed,
+			Status:  constants.Available,
+		}
+		f.applyStandingReservation(&fieldSchedule, standingReservations)
+		err = pricing.Snapshot(&fieldSchedule, field, scheduleTime, pricingRules)
+		if err != nil {
+			return err
+		}
+		fieldSchedules = append(fieldSchedules, fieldSchedule)
+	}
+
+	return f.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		err := f.LockFieldGroups(ctx, tx, []uint{field.ID})
+		if err != nil {
+			return err
+		}
+
+		err = f.repository.GetFieldSchedule().CreateWithTx(ctx, tx, fieldSchedules)
+		if err != nil {
+			return err
+		}
+
+		return f.blockGeneratedSchedules(ctx, tx, fieldSchedules)
+	})
+}
+
+func (f *FieldScheduleService) Update(ctx context.Context, uuid string, request *dto.UpdateFieldScheduleRequest) (*dto.FieldScheduleResponse, error) {
+	fieldSchedule, err := f.repository.GetFieldSchedule().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduleTime, err := f.repository.GetTime().FindByUUID(ctx, request.TimeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !util.IsSameTimeSet(scheduleTime.TimeSetID, fieldSchedule.Field.TimeSetID) {
+		return nil, errTimeSet.ErrTimeNotInTimeSet
+	}
+
+	dateParsed, err := util.ParseDate(request.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	isTimeExist, err := f.repository.GetFieldSchedule().FindByDateAndTimeID(ctx, request.Date, int(scheduleTime.ID), int(fieldSchedule.FieldID))
+	if err != nil {
+		return nil, err
+	}
+
+	if isTimeExist != nil && isTimeExist.ID != fieldSchedule.ID {
+		return nil, errFieldSchedule.ErrFieldScheduleIsExist
+	}
+
+	pricingRules, err := f.repository.GetPricingRule().FindAllByFieldIDs(ctx, []uint{fieldSchedule.FieldID})
+	if err != nil {
+		return nil, err
+	}
+
+	// schedule yang belum dipesan mengikuti harga di tanggal dan jam barunya
+	fieldScheduleRequest := &models.FieldSchedule{
+		Date:   dateParsed,
+		TimeID: scheduleTime.ID,
+	}
+	if fieldSchedule.Status == constants.Available {
+		err = pricing.Snapshot(fieldScheduleRequest, &fieldSchedule.Field, scheduleTime, pricingRules)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	fieldScheduleUpdated, err := f.repository.GetFieldSchedule().Update(ctx, uuid, fieldScheduleRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldScheduleUpdated.Time = *scheduleTime
+	price, err := pricing.OfSchedule(fieldScheduleUpdated, pricingRules)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.FieldScheduleResponse{
+		UUID:           fieldScheduleUpdated.UUID,
+		FieldName:      fieldScheduleUpdated.Field.Name,
+		Date:           fieldScheduleUpdated.Date.Format(time.DateOnly),
+		PricePerHour:   price.PricePerHour,
+		DurationMinute: price.DurationMinute,
+		Price:          price.Price,
+		PriceFormatted: util.RupiahFormat(&price.Price),
+		PricingRule:    price.PricingRuleName(),
+		Status:         fieldScheduleUpdated.Status.GetStatusString(),
+		Time:           fmt.Sprintf("%s - %s", scheduleTime.StartTime, scheduleTime.EndTime),
+		CreatedAt:      fieldScheduleUpdated.CreatedAt,
+		UpdatedAt:      fieldScheduleUpdated.UpdatedAt,
+	}
+
+	return response, nil
+}
+
+// LockFieldGroups mengambil advisory lock untuk setiap grup field terhubung (parent beserta child-nya)
+// secara berurutan. Lock ini diambil sebelum schedule dikunci supaya transaksi yang ikut mengunci
+// schedule field terhubung selalu mengunci dengan urutan yang sama dan tidak saling deadlock.
+func (f *FieldScheduleService) LockFieldGroups(ctx context.Context, tx *gorm.DB, fieldIDs []uint) error {
+	if len(fieldIDs) == 0 {
+		return nil
+	}
+
+	fields, err := f.repository.GetField().FindAllLinkedByIDs(ctx, tx, fieldIDs)
+	if err != nil {
+		return err
+	}
+
+	requested := make(map[uint]bool, len(fieldIDs))
+	for _, fieldID := range fieldIDs {
+		requested[fieldID] = true
+	}
+
+	seen := make(map[uint]bool)
+	rootIDs := make([]uint, 0, len(fieldIDs))
+	for _, field := range fields {
+		if !requested[field.ID] {
+			continue
+		}
+
+		rootID := field.ID
+		if field.ParentID != nil {
+			rootID = *field.ParentID
+		}
+
+		if !seen[rootID] {
+			seen[rootID] = true
+			rootIDs = append(rootIDs, rootID)
+		}
+	}
+
+	sort.Slice(rootIDs, func(i, j int) bool {
+		return rootIDs[i] < rootIDs[j]
+	})
+
+	for _, rootID := range rootIDs {
+		err = f.repository.GetFieldSchedule().AdvisoryLock(ctx, tx, constants.FieldGroupLockKey, rootID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// LockFieldSchedules mengunci semua schedule yang diminta di dalam transaksi dan
+// mengembalikan daftar schedule yang statusnya tidak termasuk allowedStatus.
+// Grup field schedule tersebut dikunci lebih dulu lewat LockFieldGroups.
+func (f *FieldScheduleService) LockFieldSchedules(
+	ctx context.Context,
+	tx *gorm.DB,
+	fieldScheduleIDs []string,
+	allowedStatus ...constants.FieldScheduleStatus,
+) ([]models.FieldSchedule, []dto.FieldScheduleConflictResponse, error) {
+	// field schedule tidak pernah berpindah field, jadi field-nya aman dibaca sebelum dikunci
+	requested, err := f.repository.GetFieldSchedule().FindAllByUUIDs(ctx, fieldScheduleIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = f.LockFieldGroups(ctx, tx, util.FieldIDs(requested))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fieldSchedules, err := f.repository.GetFieldSchedule().FindAllByUUIDsForUpdate(ctx, tx, fieldScheduleIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(fieldSchedules) != len(fieldScheduleIDs) {
+		return nil, nil, errFieldSchedule.ErrFieldScheduleNotFound
+	}
+
+	return fieldSchedules, f.conflictsOf(fieldSchedules, allowedStatus...), nil
+}
+
+// checkConsecutive memastikan schedule berada di field dan tanggal yang sama,
+// dan jam selesai setiap schedule sama dengan jam mulai schedule berikutnya.
+func (f *FieldScheduleService) checkConsecutive(fieldSchedules []models.FieldSchedule) error {
+	type slot struct {
+		startTime string
+		endTime   string
+	}
+
+	if len(fieldSchedules) == 0 {
+		return errFieldSchedule.ErrFieldScheduleNotFound
+	}
+
+	first := fieldSchedules[0]
+	slots := make([]slot, 0, len(fieldSchedules))
+	for _, fieldSchedule := range fieldSchedules {
+		if fieldSchedule.FieldID != first.FieldID {
+			return errFieldSchedule.ErrFieldScheduleNotSameField
+		}
+
+		if fieldSchedule.Date.Format(time.DateOnly) != first.Date.Format(time.DateOnly) {
+			return errFieldSchedule.ErrFieldScheduleNotSameDate
+		}
+
+		startTime, err := util.NormalizeTime(fieldSchedule.Time.StartTime)
+		if err != nil {
+			return err
+		}
+
+		endTime, err := util.NormalizeTime(fieldSchedule.Time.EndTime)
+		if err != nil {
+			return err
+		}
+
+		slots = append(slots, slot{startTime: startTime, endTime: endTime})
+	}
+
+	sort.Slice(slots, func(i, j int) bool {
+		return slots[i].startTime < slots[j].startTime
+	})
+
+	for i := 1; i < len(slots); i++ {
+		if slots[i-1].endTime != slots[i].startTime {
+			return errFieldSchedule.ErrFieldScheduleNotConsecutive
+		}
+	}
+
+	return nil
+}
+
+// conflictsOf mengembalikan schedule yang statusnya tidak termasuk allowedStatus,
+// hold yang sudah kedaluwarsa dianggap available.
+func (f *FieldScheduleService) conflictsOf(
+	fieldSchedules []models.FieldSchedule,
+	allowedStatus ...constants.FieldScheduleStatus,
+) []dto.FieldScheduleConflictResponse {
+	now := time.Now()
+	conflicts := make([]dto.FieldScheduleConflictResponse, 0)
+	for _, fieldSchedule := range fieldSchedules {
+		status := fieldSchedule.Status
+		if util.IsHoldExpired(fieldSchedule, now) {
+			status = constants.Available
+		}
+
+		allowed := false
+		for _, item := range allowedStatus {
+			if status == item {
+				allowed = true
+				break
+			}
+		}
+
+		if !allowed {
+			conflicts = append(conflicts, dto.FieldScheduleConflictResponse{
+				UUID:   fieldSchedule.UUID,
+				Date:   fieldSchedule.Date.Format(time.DateOnly),
+				Time:   fmt.Sprintf("%s - %s", fieldSchedule.Time.StartTime, fieldSchedule.Time.EndTime),
+				Status: fieldSchedule.Status.GetStatusString(),
+			})
+		}
+	}
+
+	return conflicts
+}
+
+// linkedFieldIDs memetakan setiap field ke field parent atau child yang terhubung dengannya.
+func (f *FieldScheduleService) linkedFieldIDs(ctx context.Context, tx *gorm.DB, fieldIDs []uint) (map[uint][]uint, error) {
+	fields, err := f.repository.GetField().FindAllLinkedByIDs(ctx, tx, fieldIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	requested := make(map[uint]bool, len(fieldIDs))
+	for _, id := range fieldIDs {
+		requested[id] = true
+	}
+
+	linked := make(map[uint][]uint)
+	for _, field := range fields {
+		if field.ParentID == nil || (!requested[field.ID] && !requested[*field.ParentID]) {
+			continue
+		}
+
+		linked[field.ID] = append(linked[field.ID], *field.ParentID)
+		linked[*field.ParentID] = append(linked[*field.ParentID], field.ID)
+	}
+
+	return linked, nil
+}
+
+func (f *FieldScheduleService) timesOverlap(first, second models.Time) (bool, error) {
+	firstStartTime, err := util.NormalizeTime(first.StartTime)
+	if err != nil {
+		return false, err
+	}
+
+	firstEndTime, err := util.NormalizeTime(first.EndTime)
+	if err != nil {
+		return false, err
+	}
+
+	secondStartTime, err := util.NormalizeTime(second.StartTime)
+	if err != nil {
+		return false, err
+	}
+
+	secondEndTime, err := util.NormalizeTime(second.EndTime)
+	if err != nil {
+		return false, err
+	}
+
+	return firstStartTime < secondEndTime && secondStartTime < firstEndTime, nil
+}
+
+// linkedSchedules mengunci schedule di field terhubung pada tanggal yang sama dan jam yang beririsan,
+// hasilnya berurutan sesuai fieldSchedules.
+func (f *FieldScheduleService) linkedSchedules(
+	ctx context.Context,
+	tx *gorm.DB,
+	fieldSchedules []models.FieldSchedule,
+) ([][]models.FieldSchedule, error) {
+	result := make([][]models.FieldSchedule, len(fieldSchedules))
+	if len(fieldSchedules) == 0 {
+		return result, nil
+	}
+
+	linkedFields, err := f.linkedFieldIDs(ctx, tx, util.FieldIDs(fieldSchedules))
+	if err != nil {
+		return nil, err
+	}
+
+	fieldIDs := make([]uint, 0)
+	dates := make([]string, 0)
+	seenFieldIDs := make(map[uint]bool)
+	seenDates := make(map[string]bool)
+	for _, fieldSchedule := range fieldSchedules {
+		for _, fieldID := range linkedFields[fieldSchedule.FieldID] {
+			if !seenFieldIDs[fieldID] {
+				seenFieldIDs[fieldID] = true
+				fieldIDs = append(fieldIDs, fieldID)
+			}
+		}
+
+		date := fieldSchedule.Date.Format(time.DateOnly)
+		if !seenDates[date] {
+			seenDates[date] = true
+			dates = append(dates, date)
+		}
+	}
+
+	if len(fieldIDs) == 0 {
+		return result, nil
+	}
+
+	candidates, err := f.repository.GetFieldSchedule().FindAllByFieldIDsAndDatesForUpdate(ctx, tx, fieldIDs, dates)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, fieldSchedule := range fieldSchedules {
+		for _, fieldID := range linkedFields[fieldSchedule.FieldID] {
+			for _, candidate := range candidates {
+				if candidate.FieldID != fieldID ||
+					candidate.Date.Format(time.DateOnly) != fieldSchedule.Date.Format(time.DateOnly) {
+					continue
+				}
+
+				overlap, err := f.timesOverlap(fieldSchedule.Time, candidate.Time)
+				if err != nil {
+					return nil, err
+				}
+
+				if overlap {
+					result[i] = append(result[i], candidate)
+				}
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// BlockLinkedSchedules menandai schedule field terhubung yang jamnya beririsan sebagai unavailable.
+// Jika ada schedule terhubung yang sedang dipakai, schedule tersebut dikembalikan sebagai konflik
+// dan tidak ada schedule yang diblokir.
+func (f *FieldScheduleService) BlockLinkedSchedules(
+	ctx context.Context,
+	tx *gorm.DB,
+	fieldSchedules []models.FieldSchedule,
+) ([]models.FieldSchedule, []dto.FieldScheduleConflictResponse, error) {
+	linked, err := f.linkedSchedules(ctx, tx, fieldSchedules)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// schedule terhubung yang ikut dipesan dalam permintaan yang sama tidak diblokir
+	selected := make(map[uint]bool, len(fieldSchedules))
+	for _, fieldSchedule := range fieldSchedules {
+		if fieldSchedule.ID != 0 {
+			selected[fieldSchedule.ID] = true
+		}
+	}
+
+	now := time.Now()
+	seen := make(map[uint]bool)
+	blocked := make([]models.FieldSchedule, 0)
+	conflicts := make([]dto.FieldScheduleConflictResponse, 0)
+	for _, items := range linked {
+		for _, item := range items {
+			if selected[item.ID] || seen[item.ID] || item.Status == constants.Unavailable {
+				continue
+			}
+			seen[item.ID] = true
+
+			if item.Status == constants.Available || util.IsHoldExpired(item, now) {
+				blocked = append(blocked, item)
+				continue
+			}
+
+			conflicts = append(conflicts, dto.FieldScheduleConflictResponse{
+				UUID:      item.UUID,
+				FieldName: item.Field.Name,
+				Date:      item.Date.Format(time.DateOnly),
+				Time:      fmt.Sprintf("%s - %s", item.Time.StartTime, item.Time.EndTime),
+				Status:    item.Status.GetStatusString(),
+			})
+		}
+	}
+
+	if len(conflicts) > 0 {
+		return nil, conflicts, nil
+	}
+
+	if len(blocked) == 0 {
+		return blocked, nil, nil
+	}
+
+	ids := util.FieldScheduleIDs(blocked)
+	err = f.repository.GetFieldSchedule().UpdateStatus(ctx, tx, constants.Unavailable, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = f.CloseWaitlistOffer(ctx, tx, ids, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return blocked, nil, nil
+}
+
+// UnblockLinkedSchedules membuka kembali schedule field terhubung yang unavailable jika tidak ada lagi
+// schedule terhubung lain yang booked atau held, dipanggil setelah status fieldSchedules diubah.
+func (f *FieldScheduleService) UnblockLinkedSchedules(
+	ctx context.Context,
+	tx *gorm.DB,
+	fieldSchedules []models.FieldSchedule,
+) ([]models.FieldSchedule, error) {
+	linked, err := f.linkedSchedules(ctx, tx, fieldSchedules)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[uint]bool)
+	candidates := make([]models.FieldSchedule, 0)
+	for _, items := range linked {
+		for _, item := range items {
+			if item.Status != constants.Unavailable || seen[item.ID] {
+				continue
+			}
+			seen[item.ID] = true
+			candidates = append(candidates, item)
+		}
+	}
+
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	candidateLinked, err := f.linkedSchedules(ctx, tx, candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	opened := make([]models.FieldSchedule, 0, len(candidates))
+	for i, candidate := range candidates {
+		inUse := false
+		for _, item := range candidateLinked[i] {
+			if item.Status == constants.Booked || (item.Status == constants.Held && !util.IsHoldExpired(item, now)) {
+				inUse = true
+				break
+			}
+		}
+
+		if !inUse {
+			opened = append(opened, candidate)
+		}
+	}
+
+	if len(opened) == 0 {
+		return nil, nil
+	}
+
+	err = f.repository.GetFieldSchedule().UpdateStatus(ctx, tx, constants.Available, util.FieldScheduleIDs(opened))
+	if err != nil {
+		return nil, err
+	}
+
+	return opened, nil
+}
+
+// reconcileLinkedSchedules menyesuaikan status schedule dengan relasi field saat ini. Schedule yang
+// beririsan dengan schedule terhubung yang sedang dipakai diblokir, schedule unavailable yang tidak lagi
+// punya pasangan yang dipakai dibuka kembali, dan schedule terhubung yang sama-sama dipakai
+// dikembalikan sebagai konflik tanpa ada status yang diubah.
+func (f *FieldScheduleService) reconcileLinkedSchedules(
+	ctx context.Context,
+	tx *gorm.DB,
+	fieldSchedules []models.FieldSchedule,
+) ([]dto.FieldScheduleConflictResponse, error) {
+	linked, err := f.linkedSchedules(ctx, tx, fieldSchedules)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	seen := make(map[uint]bool)
+	blocked := make([]models.FieldSchedule, 0)
+	opened := make([]models.FieldSchedule, 0)
+	conflicts := make([]dto.FieldScheduleConflictResponse, 0)
+	for i, fieldSchedule := range fieldSchedules {
+		linkedInUse := false
+		for _, item := range linked[i] {
+			if !f.isInUse(item, now) {
+				continue
+			}
+			linkedInUse = true
+
+			if f.isInUse(fieldSchedule, now) && !seen[item.ID] {
+				seen[item.ID] = true
+				conflicts = append(conflicts, dto.FieldScheduleConflictResponse{
+					UUID:      item.UUID,
+					FieldName: item.Field.Name,
+					Date:      item.Date.Format(time.DateOnly),
+					Time:      fmt.Sprintf("%s - %s", item.Time.StartTime, item.Time.EndTime),
+					Status:    item.Status.GetStatusString(),
+				})
+			}
+		}
+
+		switch {
+		case f.isInUse(fieldSchedule, now):
+			for _, item := range linked[i] {
+				if !seen[item.ID] && (item.Status == constants.Available || util.IsHoldExpired(item, now)) {
+					seen[item.ID] = true
+					blocked = append(blocked, item)
+				}
+			}
+		case linkedInUse && (fieldSchedule.Status == constants.Available || util.IsHoldExpired(fieldSchedule, now)):
+			if !seen[fieldSchedule.ID] {
+				seen[fieldSchedule.ID] = true
+				blocked = append(blocked, fieldSchedule)
+			}
+		case !linkedInUse && fieldSchedule.Status == constants.Unavailable:
+			if !seen[fieldSchedule.ID] {
+				seen[fieldSchedule.ID] = true
+				opened = append(opened, fieldSchedule)
+			}
+		}
+	}
+
+	if len(conflicts) > 0 {
+		return conflicts, nil
+	}
+
+	if len(blocked) > 0 {
+		ids := util.FieldScheduleIDs(blocked)
+		err = f.repository.GetFieldSchedule().UpdateStatus(ctx, tx, constants.Unavailable, ids)
+		if err != nil {
+			return nil, err
+		}
+
+		err = f.CloseWaitlistOffer(ctx, tx, ids, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(opened) == 0 {
+		return nil, nil
+	}
+
+	err = f.repository.GetFieldSchedule().UpdateStatus(ctx, tx, constants.Available, util.FieldScheduleIDs(opened))
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, f.OfferToWaitlist(ctx, tx, opened)
+}
+
+// isInUse mengecek apakah schedule sedang dipesan atau di-hold dan karena itu memblokir field terhubung.
+func (f *FieldScheduleService) isInUse(fieldSchedule models.FieldSchedule, now time.Time) bool {
+	return fieldSchedule.Status == constants.Booked ||
+		(fieldSchedule.Status == constants.Held && !util.IsHoldExpired(fieldSchedule, now))
+}
+
+// blockGeneratedSchedules menerapkan blokir field terhubung ke schedule yang baru dibuat. Schedule baru
+// yang beririsan dengan schedule terhubung yang sedang dipakai dibuat unavailable, termasuk schedule
+// standing reservation yang karena itu batal dipesan, dan schedule terhubung dari schedule baru yang
+// dipesan ikut diblokir.
+func (f *FieldScheduleService) blockGeneratedSchedules(
+	ctx context.Context,
+	tx *gorm.DB,
+	fieldSchedules []models.FieldSchedule,
+) error {
+	uuids := make([]string, 0, len(fieldSchedules))
+	for _, fieldSchedule := range fieldSchedules {
+		uuids = append(uuids, fieldSchedule.UUID.String())
+	}
+
+	// schedule dibaca ulang karena pada mode skip sebagian schedule tidak jadi dibuat
+	created, err := f.repository.GetFieldSchedule().FindAllByUUIDsForUpdate(ctx, tx, uuids)
+	if err != nil {
+		return err
+	}
+
+	if len(created) == 0 {
+		return nil
+	}
+
+	linked, err := f.linkedSchedules(ctx, tx, created)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	cancelled := make([]models.FieldSchedule, 0)
+	for i, fieldSchedule := range created {
+		if fieldSchedule.Status != constants.Booked {
+			continue
+		}
+
+		for _, item := range linked[i] {
+			if f.isInUse(item, now) {
+				cancelled = append(cancelled, fieldSchedule)
+				created[i].Status = constants.Unavailable
+				break
+			}
+		}
+	}
+
+	if len(cancelled) > 0 {
+		ids := util.FieldScheduleIDs(cancelled)
+		err = f.repository.GetFieldSchedule().UpdateStatus(ctx, tx, constants.Unavailable, ids)
+		if err != nil {
+			return err
+		}
+
+		err = f.repository.GetFieldSchedule().UpdateStandingReservationID(ctx, tx, nil, ids)
+		if err != nil {
+			return err
+		}
+	}
+
+	conflicts, err := f.reconcileLinkedSchedules(ctx, tx, created)
+	if err != nil {
+		return err
+	}
+
+	if len(conflicts) > 0 {
+		return errFieldSchedule.ErrLinkedFieldScheduleNotAvailable
+	}
+
+	return nil
+}
+
+// RelinkFieldSchedules dipanggil setelah parent field berubah, schedule field tersebut yang belum dimulai
+// disesuaikan ulang dengan relasi yang baru.
+func (f *FieldScheduleService) RelinkFieldSchedules(
+	ctx context.Context,
+	tx *gorm.DB,
+	fieldIDs []uint,
+) ([]dto.FieldScheduleConflictResponse, error) {
+	now := time.Now()
+	upcoming := make([]models.FieldSchedule, 0)
+	for _, fieldID := range fieldIDs {
+		fieldSchedules, err := f.repository.GetFieldSchedule().FindAllByFieldIDFromDateForUpdate(
+			ctx,
+			tx,
+			fieldID,
+			now.Format(time.DateOnly),
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, fieldSchedule := range fieldSchedules {
+			startAt, err := util.ScheduleStartAt(&fieldSchedule)
+			if err != nil {
+				return nil, err
+			}
+
+			if startAt.After(now) {
+				upcoming = append(upcoming, fieldSchedule)
+			}
+		}
+	}
+
+	if len(upcoming) == 0 {
+		return nil, nil
+	}
+
+	return f.reconcileLinkedSchedules(ctx, tx, upcoming)
+}
+
+func (f *FieldScheduleService) UpdateStatus(
+	ctx context.Context,
+	request *dto.UpdateStatusFieldScheduleRequest,
+) (*dto.UpdateStatusFieldScheduleResponse, error) {
+	fieldScheduleIDs := util.UniqueFieldScheduleIDs(request.FieldScheduleIDs)
+	parsedUserID, err := uuid.Parse(request.UserID)
+	if err != nil {
+		return nil, errFieldSchedule.ErrInvalidUserID
+	}
+	userID := &parsedUserID
+
+	var quote *quotePayload
+	if request.QuoteToken != "" {
+		payload, err := f.verifyQuote(request.QuoteToken, fieldScheduleIDs)
+		if err != nil {
+			return nil, err
+		}
+		quote = payload
+	}
+
+	response := &dto.UpdateStatusFieldScheduleResponse{FieldScheduleIDs: fieldScheduleIDs}
+	err = f.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		fieldSchedules, conflicts, err := f.LockFieldSchedules(ctx, tx, fieldScheduleIDs, constants.Available, constants.Held)
+		if err != nil {
+			return err
+		}
+
+		if request.RequireConsecutive {
+			err = f.checkConsecutive(fieldSchedules)
+			if err != nil {
+				return err
+			}
+		}
+
+		conflicts = append(conflicts, f.heldByOthers(fieldSchedules, userID)...)
+		if len(conflicts) > 0 {
+			response.Conflicts = conflicts
+			return errFieldSchedule.ErrFieldScheduleNotAvailable
+		}
+
+		_, conflicts, err = f.BlockLinkedSchedules(ctx, tx, fieldSchedules)
+		if err != nil {
+			return err
+		}
+
+		if len(conflicts) > 0 {
+			response.Conflicts = conflicts
+			return errFieldSchedule.ErrLinkedFieldScheduleNotAvailable
+		}
+
+		// booking dengan quote token hanya boleh jika total harga masih sama dengan quote
+		if quote != nil {
+			subtotal, err := f.subtotalOf(ctx, fieldSchedules)
+			if err != nil {
+				return err
+			}
+
+			if subtotal != quote.Subtotal {
+				return errFieldSchedule.ErrQuotePriceChanged
+			}
+		}
+
+		err = f.SnapshotPrice(ctx, tx, fieldSchedules)
+		if err != nil {
+			return err
+		}
+
+		err = f.repository.GetFieldSchedule().Book(ctx, tx, util.FieldScheduleIDs(fieldSchedules), userID)
+		if err != nil {
+			return err
+		}
+
+		return f.CloseWaitlistOffer(ctx, tx, util.FieldScheduleIDs(fieldSchedules), userID)
+	})
+	if err != nil {
+		if len(response.Conflicts) > 0 {
+			return response, err
+		}
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (f *FieldScheduleService) holdDuration() time.Duration {
+	holdTimeSecond := config.Config.FieldScheduleHoldTimeSecond
+	if holdTimeSecond <= 0 {
+		holdTimeSecond = constants.DefaultFieldScheduleHoldTimeSecond
+	}
+
+	return time.Duration(holdTimeSecond) * time.Second
+}
+
+func (f *FieldScheduleService) Hold(ctx context.Context, request *dto.HoldFieldScheduleRequest) (*dto.HoldFieldScheduleResponse, error) {
+	fieldScheduleIDs := util.UniqueFieldScheduleIDs(request.FieldScheduleIDs)
+	userID, err := uuid.Parse(request.UserID)
+	if err != nil {
+		return nil, errFieldSchedule.ErrInvalidUserID
+	}
+
+	heldUntil := time.Now().Add(f.holdDuration())
+	response := &dto.HoldFieldScheduleResponse{FieldScheduleIDs: fieldScheduleIDs}
+	err = f.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		fieldSchedules, conflicts, err := f.LockFieldSchedules(ctx, tx, fieldScheduleIDs, constants.Available)
+		if err != nil {
+			return err
+		}
+
+		if request.RequireConsecutive {
+			err = f.checkConsecutive(fieldSchedules)
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(conflicts) > 0 {
+			response.Conflicts = conflicts
+			return errFieldSchedule.ErrFieldScheduleNotAvailable
+		}
+
+		_, conflicts, err = f.BlockLinkedSchedules(ctx, tx, fieldSchedules)
+		if err != nil {
+			return err
+		}
+
+		if len(conflicts) > 0 {
+			response.Conflicts = conflicts
+			return errFieldSchedule.ErrLinkedFieldScheduleNotAvailable
+		}
+
+		err = f.SnapshotPrice(ctx, tx, fieldSchedules)
+		if err != nil {
+			return err
+		}
+
+		return f.repository.GetFieldSchedule().Hold(ctx, tx, util.FieldScheduleIDs(fieldSchedules), heldUntil, &userID)
+	})
+	if err != nil {
+		if len(response.Conflicts) > 0 {
+			return response, err
+		}
+		return nil, err
+	}
+
+	response.HeldUntil = &heldUntil
+	return response, nil
+}
+
+func (f *FieldScheduleService) quoteExpiry() time.Duration {
+	quoteExpirySecond := config.Config.QuoteExpirySecond
+	if quoteExpirySecond <= 0 {
+		quoteExpirySecond = constants.DefaultQuoteExpirySecond
+	}
+
+	return time.Duration(quoteExpirySecond) * time.Second
+}
+
+func (f *FieldScheduleService) quoteSignature(encodedPayload string) string {
+	mac := hmac.New(sha256.New, []byte(config.Config.SignatureKey))
+	mac.Write([]byte(encodedPayload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// signQuote membuat token dengan format <payload base64>.<hmac sha256 payload>.
+func (f *FieldScheduleService) signQuote(payload *quotePayload) (string, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	encodedPayload := base64.RawURLEncoding.EncodeToString(data)
+	return fmt.Sprintf("%s.%s", encodedPayload, f.quoteSignature(encodedPayload)), nil
+}
+
+// verifyQuote memastikan token ditandatangani service ini, belum kedaluwarsa
+// dan berisi schedule yang sama dengan request.
+func (f *FieldScheduleService) verifyQuote(token string, fieldScheduleIDs []string) (*quotePayload, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return nil, errFieldSchedule.ErrInvalidQuoteToken
+	}
+
+	if !hmac.Equal([]byte(parts[1]), []byte(f.quoteSignature(parts[0]))) {
+		return nil, errFieldSchedule.ErrInvalidQuoteToken
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, errFieldSchedule.ErrInvalidQuoteToken
+	}
+
+	var payload quotePayload
+	err = json.Unmarshal(data, &payload)
+	if err != nil {
+		return nil, errFieldSchedule.ErrInvalidQuoteToken
+	}
+
+	if time.Now().Unix() > payload.ExpiredAt {
+		return nil, errFieldSchedule.ErrQuoteTokenExpired
+	}
+
+	requestIDs := slices.Clone(fieldScheduleIDs)
+	slices.Sort(requestIDs)
+	if !slices.Equal(payload.FieldScheduleIDs, requestIDs) {
+		return nil, errFieldSchedule.ErrInvalidQuoteToken
+	}
+
+	return &payload, nil
+}
+
+// subtotalOf menjumlahkan harga schedule dengan aturan yang sama seperti saat quote dibuat.
+func (f *FieldScheduleService) subtotalOf(ctx context.Context, fieldSchedules []models.FieldSchedule) (float64, error) {
+	pricingRules, err := f.repository.GetPricingRule().FindAllByFieldIDs(ctx, util.FieldIDs(fieldSchedules))
+	if err != nil {
+		return 0, err
+	}
+
+	subtotal := float64(0)
+	for _, fieldSchedule := range fieldSchedules {
+		price, err := pricing.OfSchedule(&fieldSchedule, pricingRules)
+		if err != nil {
+			return 0, err
+		}
+		subtotal += price.Price
+	}
+
+	return subtotal, nil
+}
+
+func (f *FieldScheduleService) Quote(
+	ctx context.Context,
+	request *dto.QuoteFieldScheduleRequest,
+) (*dto.QuoteFieldScheduleResponse, error) {
+	fieldScheduleIDs := util.UniqueFieldScheduleIDs(request.FieldScheduleIDs)
+	fieldSchedules, err := f.repository.GetFieldSchedule().FindAllByUUIDs(ctx, fieldScheduleIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(fieldSchedules) != len(fieldScheduleIDs) {
+		return nil, errFieldSchedule.ErrFieldScheduleNotFound
+	}
+
+	field := fieldSchedules[0].Field
+	for _, fieldSchedule := range fieldSchedules {
+		if fieldSchedule.FieldID != field.ID {
+			return nil, errFieldSchedule.ErrFieldScheduleNotSameField
+		}
+	}
+
+	conflicts := f.conflictsOf(fieldSchedules, constants.Available)
+	if len(conflicts) > 0 {
+		return &dto.QuoteFieldScheduleResponse{Conflicts: conflicts}, errFieldSchedule.ErrFieldScheduleNotAvailable
+	}
+
+	now := time.Now()
+	startAts := make(map[uint]time.Time, len(fieldSchedules))
+	for _, fieldSchedule := range fieldSchedules {
+		startAt, err := util.ScheduleStartAt(&fieldSchedule)
+		if err != nil {
+			return nil, err
+		}
+
+		if !startAt.After(now) {
+			return nil, errFieldSchedule.ErrFieldScheduleInPast
+		}
+		startAts[fieldSchedule.ID] = startAt
+	}
+
+	sort.Slice(fieldSchedules, func(i, j int) bool {
+		return startAts[fieldSchedules[i].ID].Before(startAts[fieldSchedules[j].ID])
+	})
+
+	pricingRules, err := f.repository.GetPricingRule().FindAllByFieldIDs(ctx, []uint{field.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	subtotal := float64(0)
+	items := make([]dto.QuoteItemResponse, 0, len(fieldSchedules))
+	appliedRules := make([]string, 0)
+	for _, fieldSchedule := range fieldSchedules {
+		price, err := pricing.OfSchedule(&fieldSchedule, pricingRules)
+		if err != nil {
+			return nil, err
+		}
+
+		pricingRuleName := price.PricingRuleName()
+		if pricingRuleName != "" && !slices.Contains(appliedRules, pricingRuleName) {
+			appliedRules = append(appliedRules, pricingRuleName)
+		}
+
+		subtotal += price.Price
+		items = append(items, dto.QuoteItemResponse{
+			UUID:           fieldSchedule.UUID,
+			Date:           fieldSchedule.Date.Format(time.DateOnly),
+			Time:           fmt.Sprintf("%s - %s", fieldSchedule.Time.StartTime, fieldSchedule.Time.EndTime),
+			DurationMinute: price.DurationMinute,
+			PricePerHour:   price.PricePerHour,
+			Price:          price.Price,
+			PriceFormatted: util.RupiahFormat(&price.Price),
+			PricingRule:    pricingRuleName,
+		})
+	}
+
+	sortedIDs := slices.Clone(fieldScheduleIDs)
+	slices.Sort(sortedIDs)
+	expiredAt := now.Add(f.quoteExpiry())
+	quoteToken, err := f.signQuote(&quotePayload{
+		FieldScheduleIDs: sortedIDs,
+		Subtotal:         subtotal,
+		ExpiredAt:        expiredAt.Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.QuoteFieldScheduleResponse{
+		FieldID:           field.UUID,
+		FieldName:         field.Name,
+		Items:             items,
+		Subtotal:          subtotal,
+		SubtotalFormatted: util.RupiahFormat(&subtotal),
+		PricingRules:      appliedRules,
+		QuoteToken:        quoteToken,
+		ExpiredAt:         expiredAt,
+	}
+
+	return response, nil
+}
+
+// ReleaseExpiredHold membuka hold yang sudah kedaluwarsa, tawaran waitlist yang tidak dipakai dianggap expired
+// lalu schedule ditawarkan ke antrian berikutnya.
+func (f *FieldScheduleService) ReleaseExpiredHold(ctx context.Context) (int64, error) {
+	var total int64
+	now := time.Now()
+	fieldIDs, err := f.repository.GetFieldSchedule().FindAllFieldIDsWithExpiredHold(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(fieldIDs) == 0 {
+		return 0, nil
+	}
+
+	err = f.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		err := f.LockFieldGroups(ctx, tx, fieldIDs)
+		if err != nil {
+			return err
+		}
+
+		fieldSchedules, err := f.repository.GetFieldSchedule().FindAllExpiredHoldForUpdate(ctx, tx, now, fieldIDs)
+		if err != nil {
+			return err
+		}
+
+		if len(fieldSchedules) == 0 {
+			return nil
+		}
+
+		err = f.repository.GetFieldSchedule().UpdateStatus(ctx, tx, constants.Available, util.FieldScheduleIDs(fieldSchedules))
+		if err != nil {
+			return err
+		}
+
+		total = int64(len(fieldSchedules))
+		return f.releaseLinkedSchedules(ctx, tx, fieldSchedules, fieldSchedules)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+func (f *FieldScheduleService) Release(ctx context.Context, request *dto.ReleaseFieldScheduleRequest, releasedBy string) error {
+	fieldScheduleIDs := util.UniqueFieldScheduleIDs(request.FieldScheduleIDs)
+	return f.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		fieldSchedules, _, err := f.LockFieldSchedules(ctx, tx, fieldScheduleIDs)
+		if err != nil {
+			return err
+		}
+
+		// hanya schedule booked atau held yang di-release, schedule maintenance dibuka lewat admin
+		released := make([]models.FieldSchedule, 0, len(fieldSchedules))
+		histories := make([]models.FieldScheduleHistory, 0, len(fieldSchedules))
+		for _, fieldSchedule := range fieldSchedules {
+			if fieldSchedule.Status != constants.Booked && fieldSchedule.Status != constants.Held {
+				continue
+			}
+
+			released = append(released, fieldSchedule)
+			histories = append(histories, models.FieldScheduleHistory{
+				FieldScheduleID: fieldSchedule.ID,
+				Status:          constants.Available,
+				Reason:          request.Reason,
+				CreatedBy:       releasedBy,
+			})
+		}
+
+		if len(released) == 0 {
+			return nil
+		}
+
+		err = f.repository.GetFieldSchedule().UpdateStatus(ctx, tx, constants.Available, util.FieldScheduleIDs(released))
+		if err != nil {
+			return err
+		}
+
+		err = f.repository.GetFieldScheduleHistory().Create(ctx, tx, histories)
+		if err != nil {
+			return err
+		}
+
+		// schedule yang di-release tidak lagi menjadi bagian dari standing reservation atau event
+		err = f.repository.GetFieldSchedule().UpdateStandingReservationID(ctx, tx, nil, util.FieldScheduleIDs(released))
+		if err != nil {
+			return err
+		}
+
+		err = f.repository.GetFieldSchedule().UpdateEventID(ctx, tx, nil, util.FieldScheduleIDs(released))
+		if err != nil {
+			return err
+		}
+
+		return f.releaseLinkedSchedules(ctx, tx, released, released)
+	})
+}
+
+// releaseLinkedSchedules dipanggil setelah schedule berhenti dipakai. Tawaran waitlist pada schedule tersebut
+// ditutup, schedule terhubung yang tidak lagi terblokir dibuka, lalu schedule available ditawarkan ke waitlist.
+// Schedule yang dihapus tidak ikut ditawarkan, jadi available hanya berisi schedule yang masih bisa dipesan.
+func (f *FieldScheduleService) releaseLinkedSchedules(
+	ctx context.Context,
+	tx *gorm.DB,
+	released []models.FieldSchedule,
+	available []models.FieldSchedule,
+) error {
+	err := f.CloseWaitlistOffer(ctx, tx, util.FieldScheduleIDs(released), nil)
+	if err != nil {
+		return err
+	}
+
+	opened, err := f.UnblockLinkedSchedules(ctx, tx, released)
+	if err != nil {
+		return err
+	}
+
+	return f.OfferToWaitlist(ctx, tx, append(available, opened...))
+}
+
+func (f *FieldScheduleService) currentUsername(ctx context.Context) string {
+	user, ok := ctx.Value(constants.User).(*clientUser.UserData)
+	if !ok {
+		return ""
+	}
+
+	return user.Username
+}
+
+// lockFieldSchedulesInRange mengunci schedule milik field dalam rentang tanggal dan jam pada request.
+func (f *FieldScheduleService) lockFieldSchedulesInRange(
+	ctx context.Context,
+	tx *gorm.DB,
+	request *dto.MaintenanceFieldScheduleRequest,
+) ([]models.FieldSchedule, error) {
+	field, err := f.repository.GetField().FindByUUID(ctx, request.FieldID)
+	if err != nil {
+		return nil, err
+	}
+
+	startDate, err := util.ParseDate(request.StartDate)
+	if err != nil {
+		return nil, err
+	}
+
+	endDate, err := util.ParseDate(request.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	if endDate.Before(startDate) {
+		return nil, errFieldSchedule.ErrInvalidDateRange
+	}
+
+	startTime, endTime := "00:00:00", "24:00:00"
+	if request.StartTime != "" {
+		startTime, err = util.NormalizeTime(request.StartTime)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if request.EndTime != "" {
+		endTime, err = util.NormalizeTime(request.EndTime)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	fieldSchedules, err := f.repository.GetFieldSchedule().FindAllByDateRangeForUpdate(
+		ctx,
+		tx,
+		&field.ID,
+		request.StartDate,
+		request.EndDate,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]models.FieldSchedule, 0, len(fieldSchedules))
+	for _, fieldSchedule := range fieldSchedules {
+		scheduleStartTime, err := util.NormalizeTime(fieldSchedule.Time.StartTime)
+		if err != nil {
+			return nil, err
+		}
+
+		scheduleEndTime, err := util.NormalizeTime(fieldSchedule.Time.EndTime)
+		if err != nil {
+			return nil, err
+		}
+
+		if scheduleStartTime < endTime && scheduleEndTime > startTime {
+			result = append(result, fieldSchedule)
+		}
+	}
+
+	return result, nil
+}
+
+func (f *FieldScheduleService) StartMaintenance(
+	ctx context.Context,
+	request *dto.MaintenanceFieldScheduleRequest,
+) (*dto.MaintenanceFieldScheduleResponse, error) {
+	response := &dto.MaintenanceFieldScheduleResponse{
+		FieldScheduleIDs: make([]uuid.UUID, 0),
+		Conflicts:        make([]dto.FieldScheduleConflictResponse, 0),
+	}
+	err := f.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		fieldSchedules, err := f.lockFieldSchedulesInRange(ctx, tx, request)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		blocked := make([]models.FieldSchedule, 0, len(fieldSchedules))
+		histories := make([]models.FieldScheduleHistory, 0, len(fieldSchedules))
+		for _, fieldSchedule := range fieldSchedules {
+			if fieldSchedule.Status == constants.Maintenance {
+				continue
+			}
+
+			// schedule yang sudah dipesan tidak diubah dan dilaporkan sebagai konflik
+			if fieldSchedule.Status != constants.Available && !util.IsHoldExpired(fieldSchedule, now) {
+				response.Conflicts = append(response.Conflicts, dto.FieldScheduleConflictResponse{
+					UUID:   fieldSchedule.UUID,
+					Date:   fieldSchedule.Date.Format(time.DateOnly),
+					Time:   fmt.Sprintf("%s - %s", fieldSchedule.Time.StartTime, fieldSchedule.Time.EndTime),
+					Status: fieldSchedule.Status.GetStatusString(),
+				})
+				continue
+			}
+
+			blocked = append(blocked, fieldSchedule)
+			response.FieldScheduleIDs = append(response.FieldScheduleIDs, fieldSchedule.UUID)
+			histories = append(histories, models.FieldScheduleHistory{
+				FieldScheduleID: fieldSchedule.ID,
+				Status:          constants.Maintenance,
+				Reason:          request.Note,
+				CreatedBy:       f.currentUsername(ctx),
+			})
+		}
+
+		if len(blocked) == 0 {
+			return nil
+		}
+
+		err = f.repository.GetFieldSchedule().UpdateStatus(ctx, tx, constants.Maintenance, util.FieldScheduleIDs(blocked))
+		if err != nil {
+			return err
+		}
+
+		return f.repository.GetFieldScheduleHistory().Create(ctx, tx, histories)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (f *FieldScheduleService) FinishMaintenance(
+	ctx context.Context,
+	request *dto.MaintenanceFieldScheduleRequest,
+) (*dto.MaintenanceFieldScheduleResponse, error) {
+	response := &dto.MaintenanceFieldScheduleResponse{FieldScheduleIDs: make([]uuid.UUID, 0)}
+	err := f.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		fieldSchedules, err := f.lockFieldSchedulesInRange(ctx, tx, request)
+		if err != nil {
+			return err
+		}
+
+		opened := make([]models.FieldSchedule, 0, len(fieldSchedules))
+		histories := make([]models.FieldScheduleHistory, 0, len(fieldSchedules))
+		for _, fieldSchedule := range fieldSchedules {
+			if fieldSchedule.Status != constants.Maintenance {
+				continue
+			}
+
+			opened = append(opened, fieldSchedule)
+			response.FieldScheduleIDs = append(response.FieldScheduleIDs, fieldSchedule.UUID)
+			histories = append(histories, models.FieldScheduleHistory{
+				FieldScheduleID: fieldSchedule.ID,
+				Status:          constants.Available,
+				Reason:          request.Note,
+				CreatedBy:       f.currentUsername(ctx),
+			})
+		}
+
+		if len(opened) == 0 {
+			return nil
+		}
+
+		err = f.repository.GetFieldSchedule().UpdateStatus(ctx, tx, constants.Available, util.FieldScheduleIDs(opened))
+		if err != nil {
+			return err
+		}
+
+		err = f.repository.GetFieldScheduleHistory().Create(ctx, tx, histories)
+		if err != nil {
+			return err
+		}
+
+		// schedule yang dibuka diblokir lagi jika field terhubungnya sedang dipakai
+		for i := range opened {
+			opened[i].Status = constants.Available
+		}
+		_, err = f.reconcileLinkedSchedules(ctx, tx, opened)
+		if err != nil {
+			return err
+		}
+
+		return f.releaseLinkedSchedules(ctx, tx, opened, opened)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Delete menghapus schedule yang tidak sedang dipesan atau di-hold, schedule terhubung yang
+// diblokir karena schedule tersebut ikut dibuka.
+func (f *FieldScheduleService) Delete(ctx context.Context, uuid string) error {
+	return f.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		fieldSchedules, _, err := f.LockFieldSchedules(ctx, tx, []string{uuid})
+		if err != nil {
+			return err
+		}
+
+		if f.isInUse(fieldSchedules[0], time.Now()) {
+			return errFieldSchedule.ErrFieldScheduleInUse
+		}
+
+		err = f.repository.GetFieldSchedule().DeleteByIDs(ctx, tx, util.FieldScheduleIDs(fieldSchedules))
+		if err != nil {
+			return err
+		}
+
+		return f.releaseLinkedSchedules(ctx, tx, fieldSchedules, nil)
+	})
+}
+
+// heldByOthers mengembalikan schedule yang sedang di-hold oleh user lain, baik lewat hold biasa maupun tawaran waitlist.
+func (f *FieldScheduleService) heldByOthers(
+	fieldSchedules []models.FieldSchedule,
+	userID *uuid.UUID,
+) []dto.FieldScheduleConflictResponse {
+	now := time.Now()
+	conflicts := make([]dto.FieldScheduleConflictResponse, 0)
+	for _, fieldSchedule := range fieldSchedules {
+		if fieldSchedule.Status != constants.Held || fieldSchedule.HeldBy == nil || util.IsHoldExpired(fieldSchedule, now) {
+			continue
+		}
+
+		if userID != nil && *fieldSchedule.HeldBy == *userID {
+			continue
+		}
+
+		conflicts = append(conflicts, dto.FieldScheduleConflictResponse{
+			UUID:   fieldSchedule.UUID,
+			Date:   fieldSchedule.Date.Format(time.DateOnly),
+			Time:   fmt.Sprintf("%s - %s", fieldSchedule.Time.StartTime, fieldSchedule.Time.EndTime),
+			Status: fieldSchedule.Status.GetStatusString(),
+		})
+	}
+
+	return conflicts
+}
+
+// CloseWaitlistOffer menandai tawaran waitlist milik userID sebagai fulfilled,
+// tawaran lain pada schedule tersebut dianggap expired.
+func (f *FieldScheduleService) CloseWaitlistOffer(ctx context.Context, tx *gorm.DB, fieldScheduleIDs []uint, userID *uuid.UUID) error {
+	if userID != nil {
+		err := f.repository.GetWaitlist().UpdateStatusByFieldScheduleIDs(
+			ctx, tx, fieldScheduleIDs, userID, constants.WaitlistOffered, constants.WaitlistFulfilled,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return f.repository.GetWaitlist().UpdateStatusByFieldScheduleIDs(
+		ctx, tx, fieldScheduleIDs, nil, constants.WaitlistOffered, constants.WaitlistExpired,
+	)
+}
+
+// OfferToWaitlist menawarkan schedule yang baru tersedia ke antrian pertama dengan hold khusus untuk user tersebut.
+func (f *FieldScheduleService) OfferToWaitlist(ctx context.Context, tx *gorm.DB, fieldSchedules []models.FieldSchedule) error {
+	now := time.Now()
+	upcoming := make(map[uint]models.FieldSchedule, len(fieldSchedules))
+	for _, fieldSchedule := range fieldSchedules {
+		startAt, err := util.ScheduleStartAt(&fieldSchedule)
+		if err != nil {
+			return err
+		}
+
+		if startAt.After(now) {
+			upcoming[fieldSchedule.ID] = fieldSchedule
+		}
+	}
+
+	if len(upcoming) == 0 {
+		return nil
+	}
+
+	ids := make([]uint, 0, len(upcoming))
+	for id := range upcoming {
+		ids = append(ids, id)
+	}
+
+	waitlists, err := f.repository.GetWaitlist().FindAllWaitingByFieldScheduleIDsForUpdate(ctx, tx, ids)
+	if err != nil {
+		return err
+	}
+
+	heldUntil := now.Add(f.holdDuration())
+	offered := make([]models.FieldSchedule, 0, len(upcoming))
+	for _, waitlist := range waitlists {
+		fieldSchedule, ok := upcoming[waitlist.FieldScheduleID]
+		if !ok {
+			continue
+		}
+		delete(upcoming, waitlist.FieldScheduleID)
+
+		// schedule yang ditawarkan ikut memblokir field terhubung, schedule yang terblokir tidak ditawarkan lagi
+		blocked, conflicts, err := f.BlockLinkedSchedules(ctx, tx, []models.FieldSchedule{fieldSchedule})
+		if err != nil {
+			return err
+		}
+
+		if len(conflicts) > 0 {
+			continue
+		}
+
+		for _, item := range blocked {
+			delete(upcoming, item.ID)
+		}
+
+		err = f.repository.GetFieldSchedule().Hold(ctx, tx, []uint{fieldSchedule.ID}, heldUntil, &waitlist.UserID)
+		if err != nil {
+			return err
+		}
+
+		err = f.repository.GetWaitlist().Offer(ctx, tx, waitlist.ID, heldUntil)
+		if err != nil {
+			return err
+		}
+		offered = append(offered, fieldSchedule)
+	}
+
+	return f.SnapshotPrice(ctx, tx, offered)
+}
+
+// applyStandingReservation menandai schedule baru sebagai booked jika cocok dengan standing reservation aktif.
+func (f *FieldScheduleService) applyStandingReservation(
+	fieldSchedule *models.FieldSchedule,
+	standingReservations []models.StandingReservation,
+) {
+	currentDate := fieldSchedule.Date.Format(time.DateOnly)
+	for i := range standingReservations {
+		standingReservation := &standingReservations[i]
+		if standingReservation.TimeID != fieldSchedule.TimeID ||
+			time.Weekday(standingReservation.Weekday) != fieldSchedule.Date.Weekday() ||
+			currentDate < standingReservation.StartDate.Format(time.DateOnly) ||
+			currentDate > standingReservation.EndDate.Format(time.DateOnly) {
+			continue
+		}
+
+		fieldSchedule.Status = constants.Booked
+		fieldSchedule.BookedBy = &standingReservation.OwnerID
+		fieldSchedule.StandingReservationID = &standingReservation.ID
+		return
+	}
 }
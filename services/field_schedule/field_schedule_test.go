@@ -0,0 +1,181 @@
+package services
+
+import (
+	"errors"
+	"field-service/common/pricing"
+	"field-service/config"
+	errFieldSchedule "field-service/constants/error/fieldSchedule"
+	"field-service/domain/models"
+	"slices"
+	"testing"
+	"time"
+)
+
+func newFieldSchedule(fieldID uint, date time.Time, startTime, endTime string) models.FieldSchedule {
+	return models.FieldSchedule{
+		FieldID: fieldID,
+		Date:    date,
+		Time:    models.Time{StartTime: startTime, EndTime: endTime},
+	}
+}
+
+func TestCheckConsecutive(t *testing.T) {
+	date := time.Date(2026, time.October, 19, 0, 0, 0, 0, time.Local)
+	tests := []struct {
+		name           string
+		fieldSchedules []models.FieldSchedule
+		err            error
+	}{
+		{
+			name: "empty",
+			err:  errFieldSchedule.ErrFieldScheduleNotFound,
+		},
+		{
+			name:           "single schedule",
+			fieldSchedules: []models.FieldSchedule{newFieldSchedule(1, date, "08:00:00", "09:00:00")},
+		},
+		{
+			name: "consecutive in any order",
+			fieldSchedules: []models.FieldSchedule{
+				newFieldSchedule(1, date, "10:00:00", "11:00:00"),
+				newFieldSchedule(1, date, "08:00:00", "09:00:00"),
+				newFieldSchedule(1, date, "09:00", "10:00"),
+			},
+		},
+		{
+			name: "gap between schedules",
+			fieldSchedules: []models.FieldSchedule{
+				newFieldSchedule(1, date, "08:00:00", "09:00:00"),
+				newFieldSchedule(1, date, "10:00:00", "11:00:00"),
+			},
+			err: errFieldSchedule.ErrFieldScheduleNotConsecutive,
+		},
+		{
+			name: "different field",
+			fieldSchedules: []models.FieldSchedule{
+				newFieldSchedule(1, date, "08:00:00", "09:00:00"),
+				newFieldSchedule(2, date, "09:00:00", "10:00:00"),
+			},
+			err: errFieldSchedule.ErrFieldScheduleNotSameField,
+		},
+		{
+			name: "different date",
+			fieldSchedules: []models.FieldSchedule{
+				newFieldSchedule(1, date, "08:00:00", "09:00:00"),
+				newFieldSchedule(1, date.AddDate(0, 0, 1), "09:00:00", "10:00:00"),
+			},
+			err: errFieldSchedule.ErrFieldScheduleNotSameDate,
+		},
+	}
+
+	service := &FieldScheduleService{}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := service.checkConsecutive(test.fieldSchedules)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("expected error %v, got %v", test.err, err)
+			}
+		})
+	}
+}
+
+func TestConsecutiveRuns(t *testing.T) {
+	date := time.Date(2026, time.October, 19, 0, 0, 0, 0, time.Local)
+	fieldSchedules := []models.FieldSchedule{
+		newFieldSchedule(1, date, "08:00:00", "09:00:00"),
+		newFieldSchedule(1, date, "09:00:00", "10:00:00"),
+		newFieldSchedule(1, date, "11:00:00", "12:00:00"),
+		newFieldSchedule(1, date, "13:00:00", "14:00:00"),
+		newFieldSchedule(1, date, "14:00:00", "14:30:00"),
+		newFieldSchedule(1, date, "14:30:00", "15:00:00"),
+	}
+	prices := []*pricing.SchedulePrice{
+		{DurationMinute: 60},
+		{DurationMinute: 60},
+		{DurationMinute: 60},
+		{DurationMinute: 60},
+		{DurationMinute: 30},
+		{DurationMinute: 30},
+	}
+
+	tests := []struct {
+		name              string
+		minDurationMinute int
+		indexes           []int
+	}{
+		{name: "without minimum duration", minDurationMinute: 0, indexes: []int{0, 1, 2, 3, 4, 5}},
+		{name: "two hours minimum", minDurationMinute: 120, indexes: []int{0, 1, 3, 4, 5}},
+		{name: "longer than every run", minDurationMinute: 180, indexes: []int{}},
+	}
+
+	service := &FieldScheduleService{}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			indexes, err := service.consecutiveRuns(fieldSchedules, prices, test.minDurationMinute)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !slices.Equal(indexes, test.indexes) {
+				t.Fatalf("expected indexes %v, got %v", test.indexes, indexes)
+			}
+		})
+	}
+}
+
+func TestVerifyQuote(t *testing.T) {
+	signatureKey := config.Config.SignatureKey
+	config.Config.SignatureKey = "test-signature-key"
+	t.Cleanup(func() {
+		config.Config.SignatureKey = signatureKey
+	})
+
+	service := &FieldScheduleService{}
+	fieldScheduleIDs := []string{"a", "b"}
+	sign := func(payload *quotePayload) string {
+		token, err := service.signQuote(payload)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return token
+	}
+
+	validToken := sign(&quotePayload{
+		FieldScheduleIDs: fieldScheduleIDs,
+		Subtotal:         200000,
+		ExpiredAt:        time.Now().Add(time.Minute).Unix(),
+	})
+	expiredToken := sign(&quotePayload{
+		FieldScheduleIDs: fieldScheduleIDs,
+		Subtotal:         200000,
+		ExpiredAt:        time.Now().Add(-time.Minute).Unix(),
+	})
+
+	tests := []struct {
+		name             string
+		token            string
+		fieldScheduleIDs []string
+		err              error
+	}{
+		{name: "valid", token: validToken, fieldScheduleIDs: []string{"a", "b"}},
+		{name: "valid in different order", token: validToken, fieldScheduleIDs: []string{"b", "a"}},
+		{name: "different schedules", token: validToken, fieldScheduleIDs: []string{"a"}, err: errFieldSchedule.ErrInvalidQuoteToken},
+		{name: "tampered signature", token: validToken + "0", fieldScheduleIDs: fieldScheduleIDs, err: errFieldSchedule.ErrInvalidQuoteToken},
+		{name: "tampered payload", token: "e30" + validToken, fieldScheduleIDs: fieldScheduleIDs, err: errFieldSchedule.ErrInvalidQuoteToken},
+		{name: "malformed", token: "quote", fieldScheduleIDs: fieldScheduleIDs, err: errFieldSchedule.ErrInvalidQuoteToken},
+		{name: "expired", token: expiredToken, fieldScheduleIDs: fieldScheduleIDs, err: errFieldSchedule.ErrQuoteTokenExpired},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			payload, err := service.verifyQuote(test.token, test.fieldScheduleIDs)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("expected error %v, got %v", test.err, err)
+			}
+
+			if err == nil && payload.Subtotal != 200000 {
+				t.Fatalf("expected subtotal 200000, got %.0f", payload.Subtotal)
+			}
+		})
+	}
+}
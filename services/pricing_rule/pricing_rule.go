@@ -0,0 +1,206 @@
+package services
+
+import (
+	"context"
+	"field-service/common/util"
+	errFieldSchedule "field-service/constants/error/fieldSchedule"
+	errTime "field-service/constants/error/time"
+	"field-service/domain/dto"
+	"field-service/domain/models"
+	"field-service/repositories"
+	"github.com/lib/pq"
+	"time"
+)
+
+type PricingRuleService struct {
+	repository repositories.IRepositoryRegistry
+}
+
+type IPricingRuleService interface {
+	GetAll(context.Context, *dto.PricingRuleRequestParam) ([]dto.PricingRuleResponse, error)
+	GetByUUID(context.Context, string) (*dto.PricingRuleResponse, error)
+	Create(context.Context, *dto.PricingRuleRequest) (*dto.PricingRuleResponse, error)
+	Update(context.Context, string, *dto.PricingRuleRequest) (*dto.PricingRuleResponse, error)
+	Delete(context.Context, string) error
+}
+
+func NewPricingRuleService(repository repositories.IRepositoryRegistry) IPricingRuleService {
+	return &PricingRuleService{repository: repository}
+}
+
+func (p *PricingRuleService) formatDate(date *time.Time) *string {
+	if date == nil {
+		return nil
+	}
+
+	formatted := date.Format(time.DateOnly)
+	return &formatted
+}
+
+func (p *PricingRuleService) toResponse(pricingRule *models.PricingRule, field *models.Field) dto.PricingRuleResponse {
+	weekdays := make([]int, 0, len(pricingRule.Weekdays))
+	for _, weekday := range pricingRule.Weekdays {
+		weekdays = append(weekdays, int(weekday))
+	}
+
+	return dto.PricingRuleResponse{
+		UUID:         pricingRule.UUID,
+		FieldID:      field.UUID,
+		FieldName:    field.Name,
+		Name:         pricingRule.Name,
+		Weekdays:     weekdays,
+		StartTime:    pricingRule.StartTime,
+		EndTime:      pricingRule.EndTime,
+		StartDate:    p.formatDate(pricingRule.StartDate),
+		EndDate:      p.formatDate(pricingRule.EndDate),
+		PricePerHour: pricingRule.PricePerHour,
+		Priority:     pricingRule.Priority,
+		CreatedAt:    pricingRule.CreatedAt,
+		UpdatedAt:    pricingRule.UpdatedAt,
+	}
+}
+
+func (p *PricingRuleService) GetAll(ctx context.Context, param *dto.PricingRuleRequestParam) ([]dto.PricingRuleResponse, error) {
+	var fieldID *uint
+	if param.FieldID != "" {
+		field, err := p.repository.GetField().FindByUUID(ctx, param.FieldID)
+		if err != nil {
+			return nil, err
+		}
+		fieldID = &field.ID
+	}
+
+	pricingRules, err := p.repository.GetPricingRule().FindAll(ctx, fieldID)
+	if err != nil {
+		return nil, err
+	}
+
+	pricingRuleResults := make([]dto.PricingRuleResponse, 0, len(pricingRules))
+	for _, pricingRule := range pricingRules {
+		pricingRuleResults = append(pricingRuleResults, p.toResponse(&pricingRule, &pricingRule.Field))
+	}
+
+	return pricingRuleResults, nil
+}
+
+func (p *PricingRuleService) GetByUUID(ctx context.Context, uuid string) (*dto.PricingRuleResponse, error) {
+	pricingRule, err := p.repository.GetPricingRule().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	response := p.toResponse(pricingRule, &pricingRule.Field)
+	return &response, nil
+}
+
+// parseOptionalDate mengubah tanggal opsional, string kosong berarti rule tidak dibatasi tanggal.
+func (p *PricingRuleService) parseOptionalDate(date string) (*time.Time, error) {
+	if date == "" {
+		return nil, nil
+	}
+
+	dateParsed, err := time.ParseInLocation(time.DateOnly, date, time.Local)
+	if err != nil {
+		return nil, errFieldSchedule.ErrInvalidDate
+	}
+
+	return &dateParsed, nil
+}
+
+func (p *PricingRuleService) buildPricingRule(ctx context.Context, request *dto.PricingRuleRequest) (*models.PricingRule, *models.Field, error) {
+	field, err := p.repository.GetField().FindByUUID(ctx, request.FieldID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	startTime, err := util.NormalizeTime(request.StartTime)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	endTime, err := util.NormalizeTime(request.EndTime)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if endTime <= startTime {
+		return nil, nil, errTime.ErrInvalidTimeRange
+	}
+
+	startDate, err := p.parseOptionalDate(request.StartDate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	endDate, err := p.parseOptionalDate(request.EndDate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if startDate != nil && endDate != nil && endDate.Before(*startDate) {
+		return nil, nil, errFieldSchedule.ErrInvalidDateRange
+	}
+
+	weekdays := make(pq.Int32Array, 0, len(request.Weekdays))
+	for _, weekday := range request.Weekdays {
+		weekdays = append(weekdays, int32(weekday))
+	}
+
+	pricingRule := &models.PricingRule{
+		FieldID:      field.ID,
+		Name:         request.Name,
+		Weekdays:     weekdays,
+		StartTime:    startTime,
+		EndTime:      endTime,
+		StartDate:    startDate,
+		EndDate:      endDate,
+		PricePerHour: request.PricePerHour,
+		Priority:     request.Priority,
+	}
+
+	return pricingRule, field, nil
+}
+
+func (p *PricingRuleService) Create(ctx context.Context, request *dto.PricingRuleRequest) (*dto.PricingRuleResponse, error) {
+	pricingRule, field, err := p.buildPricingRule(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	pricingRuleCreated, err := p.repository.GetPricingRule().Create(ctx, pricingRule)
+	if err != nil {
+		return nil, err
+	}
+
+	response := p.toResponse(pricingRuleCreated, field)
+	return &response, nil
+}
+
+func (p *PricingRuleService) Update(ctx context.Context, uuid string, request *dto.PricingRuleRequest) (*dto.PricingRuleResponse, error) {
+	pricingRule, field, err := p.buildPricingRule(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	pricingRuleUpdated, err := p.repository.GetPricingRule().Update(ctx, uuid, pricingRule)
+	if err != nil {
+		return nil, err
+	}
+
+	response := p.toResponse(pricingRuleUpdated, field)
+	return &response, nil
+}
+
+func (p *PricingRuleService) Delete(ctx context.Context, uuid string) error {
+	_, err := p.repository.GetPricingRule().FindByUUID(ctx, uuid)
+	if err != nil {
+		return err
+	}
+
+	err = p.repository.GetPricingRule().Delete(ctx, uuid)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,507 @@
+package services
+
+import (
+	"context"
+	"field-service/common/pricing"
+	"field-service/common/util"
+	"field-service/constants"
+	errFieldSchedule "field-service/constants/error/fieldSchedule"
+	errPromo "field-service/constants/error/promo"
+	errTime "field-service/constants/error/time"
+	"field-service/domain/dto"
+	"field-service/domain/models"
+	"field-service/repositories"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+	"math"
+	"strings"
+	"time"
+)
+
+type PromoService struct {
+	repository repositories.IRepositoryRegistry
+}
+
+type IPromoService interface {
+	GetAll(context.Context) ([]dto.PromoResponse, error)
+	GetByUUID(context.Context, string) (*dto.PromoResponse, error)
+	Create(context.Context, *dto.PromoRequest) (*dto.PromoResponse, error)
+	Update(context.Context, string, *dto.PromoRequest) (*dto.PromoResponse, error)
+	Delete(context.Context, string) error
+	Evaluate(context.Context, *dto.EvaluatePromoRequest) (*dto.EvaluatePromoResponse, error)
+	Redeem(context.Context, *dto.RedeemPromoRequest) (*dto.EvaluatePromoResponse, error)
+}
+
+func NewPromoService(repository repositories.IRepositoryRegistry) IPromoService {
+	return &PromoService{repository: repository}
+}
+
+func (p *PromoService) toResponse(promo *models.Promo) dto.PromoResponse {
+	weekdays := make([]int, 0, len(promo.Weekdays))
+	for _, weekday := range promo.Weekdays {
+		weekdays = append(weekdays, int(weekday))
+	}
+
+	fields := make([]dto.FieldResponse, 0, len(promo.Fields))
+	for _, field := range promo.Fields {
+		fields = append(fields, dto.FieldResponse{
+			UUID:         field.UUID,
+			Code:         field.Code,
+			Name:         field.Name,
+			PricePerHour: field.PricePerHour,
+			Images:       field.Images,
+			CreatedAt:    field.CreatedAt,
+			UpdatedAt:    field.UpdatedAt,
+		})
+	}
+
+	return dto.PromoResponse{
+		UUID:          promo.UUID,
+		Code:          promo.Code,
+		Name:          promo.Name,
+		DiscountType:  promo.DiscountType,
+		DiscountValue: promo.DiscountValue,
+		MaxDiscount:   promo.MaxDiscount,
+		StartDate:     promo.StartDate.Format(time.DateOnly),
+		EndDate:       promo.EndDate.Format(time.DateOnly),
+		Weekdays:      weekdays,
+		StartTime:     promo.StartTime,
+		EndTime:       promo.EndTime,
+		Fields:        fields,
+		UsageLimit:    promo.UsageLimit,
+		PerUserLimit:  promo.PerUserLimit,
+		FirstBooking:  promo.FirstBooking,
+		CreatedAt:     promo.CreatedAt,
+		UpdatedAt:     promo.UpdatedAt,
+	}
+}
+
+func (p *PromoService) GetAll(ctx context.Context) ([]dto.PromoResponse, error) {
+	promos, err := p.repository.GetPromo().FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	promoResults := make([]dto.PromoResponse, 0, len(promos))
+	for _, promo := range promos {
+		promoResults = append(promoResults, p.toResponse(&promo))
+	}
+
+	return promoResults, nil
+}
+
+func (p *PromoService) GetByUUID(ctx context.Context, uuid string) (*dto.PromoResponse, error) {
+	promo, err := p.repository.GetPromo().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	response := p.toResponse(promo)
+	return &response, nil
+}
+
+// parseOptionalTime mengubah jam opsional ke format HH:MM:SS, string kosong berarti tidak dibatasi.
+func (p *PromoService) parseOptionalTime(value string) (*string, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	normalized, err := util.NormalizeTime(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &normalized, nil
+}
+
+func (p *PromoService) buildPromo(ctx context.Context, request *dto.PromoRequest) (*models.Promo, error) {
+	if request.DiscountType == constants.PromoPercentage && request.DiscountValue > 100 {
+		return nil, errPromo.ErrInvalidDiscountValue
+	}
+
+	startDate, err := time.ParseInLocation(time.DateOnly, request.StartDate, time.Local)
+	if err != nil {
+		return nil, errFieldSchedule.ErrInvalidDate
+	}
+
+	endDate, err := time.ParseInLocation(time.DateOnly, request.EndDate, time.Local)
+	if err != nil {
+		return nil, errFieldSchedule.ErrInvalidDate
+	}
+
+	if endDate.Before(startDate) {
+		return nil, errFieldSchedule.ErrInvalidDateRange
+	}
+
+	startTime, err := p.parseOptionalTime(request.StartTime)
+	if err != nil {
+		return nil, err
+	}
+
+	endTime, err := p.parseOptionalTime(request.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	if startTime != nil && endTime != nil && *endTime <= *startTime {
+		return nil, errTime.ErrInvalidTimeRange
+	}
+
+	weekdays := make(pq.Int32Array, 0, len(request.Weekdays))
+	for _, weekday := range request.Weekdays {
+		weekdays = append(weekdays, int32(weekday))
+	}
+
+	fields := make([]models.Field, 0, len(request.FieldIDs))
+	for _, fieldID := range request.FieldIDs {
+		field, err := p.repository.GetField().FindByUUID(ctx, fieldID)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, *field)
+	}
+
+	promo := &models.Promo{
+		Code:          strings.ToUpper(request.Code),
+		Name:          request.Name,
+		DiscountType:  request.DiscountType,
+		DiscountValue: request.DiscountValue,
+		MaxDiscount:   request.MaxDiscount,
+		StartDate:     startDate,
+		EndDate:       endDate,
+		Weekdays:      weekdays,
+		StartTime:     startTime,
+		EndTime:       endTime,
+		UsageLimit:    request.UsageLimit,
+		PerUserLimit:  request.PerUserLimit,
+		FirstBooking:  request.FirstBooking,
+		Fields:        fields,
+	}
+
+	return promo, nil
+}
+
+func (p *PromoService) Create(ctx context.Context, request *dto.PromoRequest) (*dto.PromoResponse, error) {
+	promo, err := p.buildPromo(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	promoCreated, err := p.repository.GetPromo().Create(ctx, promo)
+	if err != nil {
+		return nil, err
+	}
+
+	response := p.toResponse(promoCreated)
+	return &response, nil
+}
+
+func (p *PromoService) Update(ctx context.Context, uuid string, request *dto.PromoRequest) (*dto.PromoResponse, error) {
+	promo, err := p.buildPromo(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	promoUpdated, err := p.repository.GetPromo().Update(ctx, uuid, promo)
+	if err != nil {
+		return nil, err
+	}
+
+	response := p.toResponse(promoUpdated)
+	return &response, nil
+}
+
+func (p *PromoService) Delete(ctx context.Context, uuid string) error {
+	_, err := p.repository.GetPromo().FindByUUID(ctx, uuid)
+	if err != nil {
+		return err
+	}
+
+	err = p.repository.GetPromo().Delete(ctx, uuid)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// eligibleRatio mengecek field dan hari schedule terhadap batasan promo lalu menghitung porsi slot
+// yang masuk rentang jam promo, batasan yang kosong berarti berlaku untuk semua.
+func (p *PromoService) eligibleRatio(promo *models.Promo, fieldSchedule *models.FieldSchedule) (float64, error) {
+	if len(promo.Fields) > 0 {
+		isField := false
+		for _, field := range promo.Fields {
+			if field.ID == fieldSchedule.FieldID {
+				isField = true
+				break
+			}
+		}
+
+		if !isField {
+			return 0, nil
+		}
+	}
+
+	if len(promo.Weekdays) > 0 && !pricing.IsWeekday(promo.Weekdays, fieldSchedule.Date) {
+		return 0, nil
+	}
+
+	var promoStartTime, promoEndTime string
+	if promo.StartTime != nil {
+		promoStartTime = *promo.StartTime
+	}
+
+	if promo.EndTime != nil {
+		promoEndTime = *promo.EndTime
+	}
+
+	// slot yang hanya sebagian masuk jam promo mendapat potongan untuk bagian itu saja
+	return pricing.CoveredRatio(&fieldSchedule.Time, promoStartTime, promoEndTime)
+}
+
+// checkLimit memastikan promo masih berlaku hari ini dan batas pemakaiannya belum habis.
+func (p *PromoService) checkLimit(ctx context.Context, tx *gorm.DB, promo *models.Promo, userID string) error {
+	today := time.Now().Format(time.DateOnly)
+	if today < promo.StartDate.Format(time.DateOnly) || today > promo.EndDate.Format(time.DateOnly) {
+		return errPromo.ErrPromoNotActive
+	}
+
+	if promo.UsageLimit != nil {
+		total, err := p.repository.GetPromoUsage().CountByPromoID(ctx, tx, promo.ID)
+		if err != nil {
+			return err
+		}
+
+		if total >= int64(*promo.UsageLimit) {
+			return errPromo.ErrPromoUsageLimitReached
+		}
+	}
+
+	if promo.PerUserLimit != nil {
+		total, err := p.repository.GetPromoUsage().CountByPromoIDAndUserID(ctx, tx, promo.ID, userID)
+		if err != nil {
+			return err
+		}
+
+		if total >= int64(*promo.PerUserLimit) {
+			return errPromo.ErrPromoUserLimitReached
+		}
+	}
+
+	return nil
+}
+
+// checkFirstBooking memastikan user belum pernah booking sebelumnya, schedule yang sedang dihitung
+// tidak ikut dihitung karena promo bisa di-redeem setelah schedule tersebut di-booking.
+func (p *PromoService) checkFirstBooking(
+	ctx context.Context,
+	tx *gorm.DB,
+	userID string,
+	fieldSchedules []models.FieldSchedule,
+) error {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return errFieldSchedule.ErrInvalidUserID
+	}
+
+	ids := make([]uint, 0, len(fieldSchedules))
+	for _, fieldSchedule := range fieldSchedules {
+		ids = append(ids, fieldSchedule.ID)
+	}
+
+	total, err := p.repository.GetFieldSchedule().CountBookedByUserID(ctx, tx, userUUID, ids)
+	if err != nil {
+		return err
+	}
+
+	if total > 0 {
+		return errPromo.ErrPromoFirstBookingOnly
+	}
+
+	return nil
+}
+
+// calculateDiscount menghitung potongan dari subtotal schedule yang memenuhi syarat promo.
+func (p *PromoService) calculateDiscount(promo *models.Promo, eligibleSubtotal float64) float64 {
+	var discount float64
+	if promo.DiscountType == constants.PromoPercentage {
+		discount = math.Round(eligibleSubtotal * float64(promo.DiscountValue) / 100)
+	} else {
+		discount = float64(promo.DiscountValue)
+	}
+
+	if promo.MaxDiscount != nil && discount > float64(*promo.MaxDiscount) {
+		discount = float64(*promo.MaxDiscount)
+	}
+
+	return math.Min(discount, eligibleSubtotal)
+}
+
+func (p *PromoService) evaluate(
+	ctx context.Context,
+	tx *gorm.DB,
+	promo *models.Promo,
+	userID string,
+	fieldScheduleIDs []string,
+) (*dto.EvaluatePromoResponse, error) {
+	err := p.checkLimit(ctx, tx, promo, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldSchedules, err := p.repository.GetFieldSchedule().FindAllByUUIDs(ctx, fieldScheduleIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(fieldSchedules) != len(fieldScheduleIDs) {
+		return nil, errFieldSchedule.ErrFieldScheduleNotFound
+	}
+
+	if promo.FirstBooking {
+		err = p.checkFirstBooking(ctx, tx, userID, fieldSchedules)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	fieldIDs := make([]uint, 0, len(fieldSchedules))
+	for _, fieldSchedule := range fieldSchedules {
+		fieldIDs = append(fieldIDs, fieldSchedule.FieldID)
+	}
+
+	pricingRules, err := p.repository.GetPricingRule().FindAllByFieldIDs(ctx, fieldIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	subtotal, eligibleSubtotal := float64(0), float64(0)
+	items := make([]dto.PromoItemResponse, 0, len(fieldSchedules))
+	for _, fieldSchedule := range fieldSchedules {
+		price, err := pricing.OfSchedule(&fieldSchedule, pricingRules)
+		if err != nil {
+			return nil, err
+		}
+
+		eligibleRatio, err := p.eligibleRatio(promo, &fieldSchedule)
+		if err != nil {
+			return nil, err
+		}
+
+		subtotal += price.Price
+		eligibleSubtotal += math.Round(price.Price * eligibleRatio)
+
+		items = append(items, dto.PromoItemResponse{
+			UUID:     fieldSchedule.UUID,
+			Date:     fieldSchedule.Date.Format(time.DateOnly),
+			Time:     fmt.Sprintf("%s - %s", fieldSchedule.Time.StartTime, fieldSchedule.Time.EndTime),
+			Price:    price.Price,
+			Eligible: eligibleRatio > 0,
+		})
+	}
+
+	if eligibleSubtotal == 0 {
+		return nil, errPromo.ErrPromoNotApplicable
+	}
+
+	discount := p.calculateDiscount(promo, eligibleSubtotal)
+	total := subtotal - discount
+	response := &dto.EvaluatePromoResponse{
+		Code:              promo.Code,
+		Name:              promo.Name,
+		Items:             items,
+		Subtotal:          subtotal,
+		EligibleSubtotal:  eligibleSubtotal,
+		Discount:          discount,
+		DiscountFormatted: util.RupiahFormat(&discount),
+		Total:             total,
+		TotalFormatted:    util.RupiahFormat(&total),
+	}
+
+	return response, nil
+}
+
+func (p *PromoService) Evaluate(ctx context.Context, request *dto.EvaluatePromoRequest) (*dto.EvaluatePromoResponse, error) {
+	db := p.repository.GetTx()
+	promo, err := p.repository.GetPromo().FindByCode(ctx, db, strings.ToUpper(request.Code), false)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.evaluate(ctx, db, promo, request.UserID, request.FieldScheduleIDs)
+}
+
+// lockOwnedSchedules mengunci schedule yang di-redeem dan memastikan semuanya sedang di-booking
+// atau di-hold oleh user yang sama, supaya promo tidak bisa dipakai untuk slot milik orang lain.
+func (p *PromoService) lockOwnedSchedules(
+	ctx context.Context,
+	tx *gorm.DB,
+	userID string,
+	fieldScheduleIDs []string,
+) ([]models.FieldSchedule, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errFieldSchedule.ErrInvalidUserID
+	}
+
+	fieldScheduleIDs = util.UniqueFieldScheduleIDs(fieldScheduleIDs)
+	fieldSchedules, err := p.repository.GetFieldSchedule().FindAllByUUIDsForUpdate(ctx, tx, fieldScheduleIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(fieldSchedules) != len(fieldScheduleIDs) {
+		return nil, errFieldSchedule.ErrFieldScheduleNotFound
+	}
+
+	now := time.Now()
+	for _, fieldSchedule := range fieldSchedules {
+		isBooked := fieldSchedule.Status == constants.Booked &&
+			fieldSchedule.BookedBy != nil &&
+			*fieldSchedule.BookedBy == userUUID
+		isHeld := fieldSchedule.Status == constants.Held &&
+			fieldSchedule.HeldBy != nil &&
+			*fieldSchedule.HeldBy == userUUID &&
+			!util.IsHoldExpired(fieldSchedule, now)
+		if !isBooked && !isHeld {
+			return nil, errPromo.ErrPromoScheduleNotOwned
+		}
+	}
+
+	return fieldSchedules, nil
+}
+
+func (p *PromoService) Redeem(ctx context.Context, request *dto.RedeemPromoRequest) (*dto.EvaluatePromoResponse, error) {
+	var response *dto.EvaluatePromoResponse
+	err := p.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		// baris promo dikunci supaya redeem bersamaan tidak melewati batas pemakaian
+		promo, err := p.repository.GetPromo().FindByCode(ctx, tx, strings.ToUpper(request.Code), true)
+		if err != nil {
+			return err
+		}
+
+		fieldSchedules, err := p.lockOwnedSchedules(ctx, tx, request.UserID, request.FieldScheduleIDs)
+		if err != nil {
+			return err
+		}
+
+		response, err = p.evaluate(ctx, tx, promo, request.UserID, request.FieldScheduleIDs)
+		if err != nil {
+			return err
+		}
+
+		return p.repository.GetPromoUsage().Create(ctx, tx, &models.PromoUsage{
+			PromoID:   promo.ID,
+			UserID:    request.UserID,
+			Reference: request.Reference,
+			Discount:  response.Discount,
+		}, util.FieldScheduleIDs(fieldSchedules))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
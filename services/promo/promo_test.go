@@ -0,0 +1,64 @@
+package services
+
+import (
+	"field-service/constants"
+	"field-service/domain/models"
+	"testing"
+)
+
+func TestCalculateDiscount(t *testing.T) {
+	maxDiscount := 20000
+	tests := []struct {
+		name             string
+		promo            models.Promo
+		eligibleSubtotal float64
+		discount         float64
+	}{
+		{
+			name:             "percentage",
+			promo:            models.Promo{DiscountType: constants.PromoPercentage, DiscountValue: 10},
+			eligibleSubtotal: 150000,
+			discount:         15000,
+		},
+		{
+			name:             "percentage is rounded",
+			promo:            models.Promo{DiscountType: constants.PromoPercentage, DiscountValue: 15},
+			eligibleSubtotal: 33333,
+			discount:         5000,
+		},
+		{
+			name:             "percentage capped by max discount",
+			promo:            models.Promo{DiscountType: constants.PromoPercentage, DiscountValue: 10, MaxDiscount: &maxDiscount},
+			eligibleSubtotal: 250000,
+			discount:         20000,
+		},
+		{
+			name:             "fixed",
+			promo:            models.Promo{DiscountType: constants.PromoFixed, DiscountValue: 15000},
+			eligibleSubtotal: 100000,
+			discount:         15000,
+		},
+		{
+			name:             "fixed larger than subtotal",
+			promo:            models.Promo{DiscountType: constants.PromoFixed, DiscountValue: 50000},
+			eligibleSubtotal: 30000,
+			discount:         30000,
+		},
+		{
+			name:             "no eligible schedule",
+			promo:            models.Promo{DiscountType: constants.PromoFixed, DiscountValue: 50000},
+			eligibleSubtotal: 0,
+			discount:         0,
+		},
+	}
+
+	service := &PromoService{}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			discount := service.calculateDiscount(&test.promo, test.eligibleSubtotal)
+			if discount != test.discount {
+				t.Fatalf("expected discount %.0f, got %.0f", test.discount, discount)
+			}
+		})
+	}
+}
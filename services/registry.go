@@ -2,9 +2,17 @@ package services
 
 import (
 	"field-service/repositories"
+	blackoutService "field-service/services/blackout"
+	eventService "field-service/services/event"
 	fieldService "field-service/services/field"
 	fieldScheduleService "field-service/services/field_schedule"
+	pricingRuleService "field-service/services/pricing_rule"
+	promoService "field-service/services/promo"
+	scheduleRuleService "field-service/services/schedule_rule"
+	standingReservationService "field-service/services/standing_reservation"
 	timeServices "field-service/services/time"
+	timeSetService "field-service/services/time_set"
+	waitlistService "field-service/services/waitlist"
 )
 
 type Registry struct {
@@ -15,6 +23,14 @@ type IServiceRegistry interface {
 	GetField() fieldService.IFieldService
 	GetFieldSchedule() fieldScheduleService.IFieldScheduleService
 	GetTime() timeServices.ITimeService
+	GetScheduleRule() scheduleRuleService.IScheduleRuleService
+	GetBlackout() blackoutService.IBlackoutService
+	GetTimeSet() timeSetService.ITimeSetService
+	GetPricingRule() pricingRuleService.IPricingRuleService
+	GetPromo() promoService.IPromoService
+	GetWaitlist() waitlistService.IWaitlistService
+	GetStandingReservation() standingReservationService.IStandingReservationService
+	GetEvent() eventService.IEventService
 }
 
 func NewServiceRegistry(repository repositories.IRepositoryRegistry) IServiceRegistry {
@@ -24,7 +40,7 @@ func NewServiceRegistry(repository repositories.IRepositoryRegistry) IServiceReg
 }
 
 func (r *Registry) GetField() fieldService.IFieldService {
-	return fieldService.NewFieldService(r.repository)
+	return fieldService.NewFieldService(r.repository, fieldScheduleService.NewFieldScheduleLocker(r.repository))
 }
 
 func (r *Registry) GetFieldSchedule() fieldScheduleService.IFieldScheduleService {
@@ -34,3 +50,35 @@ func (r *Registry) GetFieldSchedule() fieldScheduleService.IFieldScheduleService
 func (r *Registry) GetTime() timeServices.ITimeService {
 	return timeServices.NewTimeService(r.repository)
 }
+
+func (r *Registry) GetScheduleRule() scheduleRuleService.IScheduleRuleService {
+	return scheduleRuleService.NewScheduleRuleService(r.repository)
+}
+
+func (r *Registry) GetBlackout() blackoutService.IBlackoutService {
+	return blackoutService.NewBlackoutService(r.repository)
+}
+
+func (r *Registry) GetTimeSet() timeSetService.ITimeSetService {
+	return timeSetService.NewTimeSetService(r.repository)
+}
+
+func (r *Registry) GetPricingRule() pricingRuleService.IPricingRuleService {
+	return pricingRuleService.NewPricingRuleService(r.repository)
+}
+
+func (r *Registry) GetPromo() promoService.IPromoService {
+	return promoService.NewPromoService(r.repository)
+}
+
+func (r *Registry) GetWaitlist() waitlistService.IWaitlistService {
+	return waitlistService.NewWaitlistService(r.repository, fieldScheduleService.NewFieldScheduleLocker(r.repository))
+}
+
+func (r *Registry) GetStandingReservation() standingReservationService.IStandingReservationService {
+	return standingReservationService.NewStandingReservationService(r.repository, fieldScheduleService.NewFieldScheduleLocker(r.repository))
+}
+
+func (r *Registry) GetEvent() eventService.IEventService {
+	return eventService.NewEventService(r.repository, fieldScheduleService.NewFieldScheduleLocker(r.repository))
+}
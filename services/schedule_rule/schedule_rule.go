@@ -0,0 +1,185 @@
+package services
+
+import (
+	"context"
+	"field-service/common/util"
+	errFieldSchedule "field-service/constants/error/fieldSchedule"
+	errTimeSet "field-service/constants/error/timeSet"
+	"field-service/domain/dto"
+	"field-service/domain/models"
+	"field-service/repositories"
+	"github.com/lib/pq"
+	"time"
+)
+
+type ScheduleRuleService struct {
+	repository repositories.IRepositoryRegistry
+}
+
+type IScheduleRuleService interface {
+	GetAll(context.Context, *dto.ScheduleRuleRequestParam) ([]dto.ScheduleRuleResponse, error)
+	GetByUUID(context.Context, string) (*dto.ScheduleRuleResponse, error)
+	Create(context.Context, *dto.ScheduleRuleRequest) (*dto.ScheduleRuleResponse, error)
+	Update(context.Context, string, *dto.ScheduleRuleRequest) (*dto.ScheduleRuleResponse, error)
+	Delete(context.Context, string) error
+}
+
+func NewScheduleRuleService(repository repositories.IRepositoryRegistry) IScheduleRuleService {
+	return &ScheduleRuleService{repository: repository}
+}
+
+func (s *ScheduleRuleService) toResponse(scheduleRule *models.ScheduleRule, field *models.Field) dto.ScheduleRuleResponse {
+	weekdays := make([]int, 0, len(scheduleRule.Weekdays))
+	for _, weekday := range scheduleRule.Weekdays {
+		weekdays = append(weekdays, int(weekday))
+	}
+
+	times := make([]dto.TimeResponse, 0, len(scheduleRule.Times))
+	for _, item := range scheduleRule.Times {
+		times = append(times, dto.TimeResponse{
+			UUID:      item.UUID,
+			StartTime: item.StartTime,
+			EndTime:   item.EndTime,
+			CreatedAt: item.CreatedAt,
+			UpdatedAt: item.UpdatedAt,
+		})
+	}
+
+	return dto.ScheduleRuleResponse{
+		UUID:      scheduleRule.UUID,
+		FieldID:   field.UUID,
+		FieldName: field.Name,
+		Weekdays:  weekdays,
+		Times:     times,
+		StartDate: scheduleRule.StartDate.Format(time.DateOnly),
+		EndDate:   scheduleRule.EndDate.Format(time.DateOnly),
+		CreatedAt: scheduleRule.CreatedAt,
+		UpdatedAt: scheduleRule.UpdatedAt,
+	}
+}
+
+func (s *ScheduleRuleService) GetAll(ctx context.Context, param *dto.ScheduleRuleRequestParam) ([]dto.ScheduleRuleResponse, error) {
+	var fieldID *uint
+	if param.FieldID != "" {
+		field, err := s.repository.GetField().FindByUUID(ctx, param.FieldID)
+		if err != nil {
+			return nil, err
+		}
+		fieldID = &field.ID
+	}
+
+	scheduleRules, err := s.repository.GetScheduleRule().FindAll(ctx, fieldID)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduleRuleResults := make([]dto.ScheduleRuleResponse, 0, len(scheduleRules))
+	for _, scheduleRule := range scheduleRules {
+		scheduleRuleResults = append(scheduleRuleResults, s.toResponse(&scheduleRule, &scheduleRule.Field))
+	}
+
+	return scheduleRuleResults, nil
+}
+
+func (s *ScheduleRuleService) GetByUUID(ctx context.Context, uuid string) (*dto.ScheduleRuleResponse, error) {
+	scheduleRule, err := s.repository.GetScheduleRule().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	response := s.toResponse(scheduleRule, &scheduleRule.Field)
+	return &response, nil
+}
+
+func (s *ScheduleRuleService) buildScheduleRule(ctx context.Context, request *dto.ScheduleRuleRequest) (*models.ScheduleRule, *models.Field, error) {
+	field, err := s.repository.GetField().FindByUUID(ctx, request.FieldID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	startDate, err := time.ParseInLocation(time.DateOnly, request.StartDate, time.Local)
+	if err != nil {
+		return nil, nil, errFieldSchedule.ErrInvalidDate
+	}
+
+	endDate, err := time.ParseInLocation(time.DateOnly, request.EndDate, time.Local)
+	if err != nil {
+		return nil, nil, errFieldSchedule.ErrInvalidDate
+	}
+
+	if endDate.Before(startDate) {
+		return nil, nil, errFieldSchedule.ErrInvalidDateRange
+	}
+
+	times := make([]models.Time, 0, len(request.TimeIDs))
+	for _, timeID := range request.TimeIDs {
+		scheduleTime, err := s.repository.GetTime().FindByUUID(ctx, timeID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if !util.IsSameTimeSet(scheduleTime.TimeSetID, field.TimeSetID) {
+			return nil, nil, errTimeSet.ErrTimeNotInTimeSet
+		}
+		times = append(times, *scheduleTime)
+	}
+
+	weekdays := make(pq.Int32Array, 0, len(request.Weekdays))
+	for _, weekday := range request.Weekdays {
+		weekdays = append(weekdays, int32(weekday))
+	}
+
+	scheduleRule := &models.ScheduleRule{
+		FieldID:   field.ID,
+		Weekdays:  weekdays,
+		StartDate: startDate,
+		EndDate:   endDate,
+		Times:     times,
+	}
+
+	return scheduleRule, field, nil
+}
+
+func (s *ScheduleRuleService) Create(ctx context.Context, request *dto.ScheduleRuleRequest) (*dto.ScheduleRuleResponse, error) {
+	scheduleRule, field, err := s.buildScheduleRule(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduleRuleCreated, err := s.repository.GetScheduleRule().Create(ctx, scheduleRule)
+	if err != nil {
+		return nil, err
+	}
+
+	response := s.toResponse(scheduleRuleCreated, field)
+	return &response, nil
+}
+
+func (s *ScheduleRuleService) Update(ctx context.Context, uuid string, request *dto.ScheduleRuleRequest) (*dto.ScheduleRuleResponse, error) {
+	scheduleRule, field, err := s.buildScheduleRule(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduleRuleUpdated, err := s.repository.GetScheduleRule().Update(ctx, uuid, scheduleRule)
+	if err != nil {
+		return nil, err
+	}
+
+	response := s.toResponse(scheduleRuleUpdated, field)
+	return &response, nil
+}
+
+func (s *ScheduleRuleService) Delete(ctx context.Context, uuid string) error {
+	_, err := s.repository.GetScheduleRule().FindByUUID(ctx, uuid)
+	if err != nil {
+		return err
+	}
+
+	err = s.repository.GetScheduleRule().Delete(ctx, uuid)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
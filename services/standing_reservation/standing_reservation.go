@@ -0,0 +1,504 @@
+package services
+
+import (
+	"context"
+	clientUser "field-service/clients/user"
+	"field-service/common/util"
+	"field-service/constants"
+	errConstant "field-service/constants/error"
+	errFieldSchedule "field-service/constants/error/fieldSchedule"
+	errStandingReservation "field-service/constants/error/standingReservation"
+	errTimeSet "field-service/constants/error/timeSet"
+	"field-service/domain/dto"
+	"field-service/domain/models"
+	"field-service/repositories"
+	fieldScheduleService "field-service/services/field_schedule"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+type StandingReservationService struct {
+	repository    repositories.IRepositoryRegistry
+	fieldSchedule fieldScheduleService.IFieldScheduleLocker
+}
+
+type IStandingReservationService interface {
+	GetAll(context.Context) ([]dto.StandingReservationResponse, error)
+	GetByUUID(context.Context, string) (*dto.StandingReservationResponse, error)
+	Create(context.Context, *dto.StandingReservationRequest) (*dto.StandingReservationResponse, error)
+	Cancel(context.Context, string) error
+}
+
+func NewStandingReservationService(
+	repository repositories.IRepositoryRegistry,
+	fieldSchedule fieldScheduleService.IFieldScheduleLocker,
+) IStandingReservationService {
+	return &StandingReservationService{repository: repository, fieldSchedule: fieldSchedule}
+}
+
+func (s *StandingReservationService) currentUser(ctx context.Context) (*clientUser.UserData, error) {
+	user, ok := ctx.Value(constants.User).(*clientUser.UserData)
+	if !ok {
+		return nil, errConstant.ErrUnauthorized
+	}
+
+	return user, nil
+}
+
+func (s *StandingReservationService) toResponse(
+	standingReservation *models.StandingReservation,
+) dto.StandingReservationResponse {
+	return dto.StandingReservationResponse{
+		UUID:        standingReservation.UUID,
+		FieldID:     standingReservation.Field.UUID,
+		FieldName:   standingReservation.Field.Name,
+		TimeID:      standingReservation.Time.UUID,
+		Time:        fmt.Sprintf("%s - %s", standingReservation.Time.StartTime, standingReservation.Time.EndTime),
+		Weekday:     standingReservation.Weekday,
+		StartDate:   standingReservation.StartDate.Format(time.DateOnly),
+		EndDate:     standingReservation.EndDate.Format(time.DateOnly),
+		Name:        standingReservation.Name,
+		OwnerID:     standingReservation.OwnerID,
+		CancelledAt: standingReservation.CancelledAt,
+		CreatedAt:   standingReservation.CreatedAt,
+		UpdatedAt:   standingReservation.UpdatedAt,
+	}
+}
+
+// findStandingReservation mengambil standing reservation, customer hanya bisa melihat miliknya sendiri.
+func (s *StandingReservationService) findStandingReservation(
+	ctx context.Context,
+	uuid string,
+) (*models.StandingReservation, *clientUser.UserData, error) {
+	user, err := s.currentUser(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	standingReservation, err := s.repository.GetStandingReservation().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if user.Role != constants.Admin && standingReservation.OwnerID != user.UUID {
+		return nil, nil, errStandingReservation.ErrStandingReservationNotFound
+	}
+
+	return standingReservation, user, nil
+}
+
+func (s *StandingReservationService) GetAll(ctx context.Context) ([]dto.StandingReservationResponse, error) {
+	user, err := s.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var ownerID *uuid.UUID
+	if user.Role != constants.Admin {
+		ownerID = &user.UUID
+	}
+
+	standingReservations, err := s.repository.GetStandingReservation().FindAll(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	standingReservationResults := make([]dto.StandingReservationResponse, 0, len(standingReservations))
+	for _, standingReservation := range standingReservations {
+		standingReservationResults = append(standingReservationResults, s.toResponse(&standingReservation))
+	}
+
+	return standingReservationResults, nil
+}
+
+func (s *StandingReservationService) GetByUUID(ctx context.Context, uuid string) (*dto.StandingReservationResponse, error) {
+	standingReservation, _, err := s.findStandingReservation(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	response := s.toResponse(standingReservation)
+	return &response, nil
+}
+
+// buildStandingReservation memvalidasi request dan mengembalikan tanggal-tanggal yang masuk ke dalam seri.
+func (s *StandingReservationService) buildStandingReservation(
+	ctx context.Context,
+	request *dto.StandingReservationRequest,
+) (*models.StandingReservation, []time.Time, error) {
+	user, err := s.currentUser(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// standing reservation memesan slot tanpa pembayaran, jadi hanya admin yang boleh membuatnya
+	if user.Role != constants.Admin {
+		return nil, nil, errConstant.ErrForbidden
+	}
+
+	ownerID := user.UUID
+	if request.OwnerID != "" {
+		ownerID, err = uuid.Parse(request.OwnerID)
+		if err != nil {
+			return nil, nil, errFieldSchedule.ErrInvalidUserID
+		}
+	}
+
+	field, err := s.repository.GetField().FindByUUID(ctx, request.FieldID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	scheduleTime, err := s.repository.GetTime().FindByUUID(ctx, request.TimeID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !util.IsSameTimeSet(scheduleTime.TimeSetID, field.TimeSetID) {
+		return nil, nil, errTimeSet.ErrTimeNotInTimeSet
+	}
+
+	startDate, err := util.ParseDate(request.StartDate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	endDate, err := util.ParseDate(request.EndDate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if endDate.Before(startDate) {
+		return nil, nil, errFieldSchedule.ErrInvalidDateRange
+	}
+
+	if startDate.Format(time.DateOnly) < time.Now().Format(time.DateOnly) {
+		return nil, nil, errFieldSchedule.ErrFieldScheduleInPast
+	}
+
+	if endDate.After(startDate.AddDate(0, 0, constants.MaxGenerateScheduleNumberOfDays-1)) {
+		return nil, nil, errFieldSchedule.ErrDateRangeTooLong
+	}
+
+	dates := make([]time.Time, 0)
+	for currentDate := startDate; !currentDate.After(endDate); currentDate = currentDate.AddDate(0, 0, 1) {
+		if currentDate.Weekday() == time.Weekday(*request.Weekday) {
+			dates = append(dates, currentDate)
+		}
+	}
+
+	if len(dates) == 0 {
+		return nil, nil, errStandingReservation.ErrStandingReservationNoDate
+	}
+
+	standingReservation := &models.StandingReservation{
+		FieldID:   field.ID,
+		TimeID:    scheduleTime.ID,
+		Weekday:   *request.Weekday,
+		StartDate: startDate,
+		EndDate:   endDate,
+		Name:      request.Name,
+		OwnerID:   ownerID,
+		Field:     *field,
+		Time:      *scheduleTime,
+	}
+
+	return standingReservation, dates, nil
+}
+
+// standingReservationConflicts mengecek setiap tanggal dalam seri sebelum reservation dibuat,
+// tanggal yang tutup, sudah lewat atau schedule-nya tidak available dilaporkan sebagai konflik.
+func (s *StandingReservationService) standingReservationConflicts(
+	ctx context.Context,
+	standingReservation *models.StandingReservation,
+	dates []time.Time,
+) ([]models.FieldSchedule, []dto.StandingReservationConflictResponse, error) {
+	startDate := standingReservation.StartDate.Format(time.DateOnly)
+	endDate := standingReservation.EndDate.Format(time.DateOnly)
+	existingSchedules, err := s.repository.GetFieldSchedule().FindAllByFieldIDAndDateRange(
+		ctx,
+		int(standingReservation.FieldID),
+		startDate,
+		endDate,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	scheduleRules, err := s.repository.GetScheduleRule().FindAllByFieldIDAndDateRange(
+		ctx,
+		int(standingReservation.FieldID),
+		startDate,
+		endDate,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	blackouts, err := s.repository.GetBlackout().FindAllByFieldIDAndDateRange(
+		ctx,
+		int(standingReservation.FieldID),
+		startDate,
+		endDate,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	existing := make(map[string]models.FieldSchedule, len(existingSchedules))
+	for _, schedule := range existingSchedules {
+		existing[util.ScheduleKey(schedule.Date, schedule.TimeID)] = schedule
+	}
+
+	now := time.Now()
+	times := []models.Time{standingReservation.Time}
+	available := make([]models.FieldSchedule, 0, len(dates))
+	conflicts := make([]dto.StandingReservationConflictResponse, 0)
+	for _, date := range dates {
+		conflict := dto.StandingReservationConflictResponse{Date: date.Format(time.DateOnly)}
+		startAt, err := util.ScheduleStartAt(&models.FieldSchedule{Date: date, Time: standingReservation.Time})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if !startAt.After(now) {
+			conflict.Reason = "Past"
+			conflicts = append(conflicts, conflict)
+			continue
+		}
+
+		if util.IsBlackout(date, blackouts) {
+			conflict.Reason = "Blackout"
+			conflicts = append(conflicts, conflict)
+			continue
+		}
+
+		if len(util.TimesForDate(date, times, scheduleRules)) == 0 {
+			conflict.Reason = "Closed"
+			conflicts = append(conflicts, conflict)
+			continue
+		}
+
+		// tanggal yang schedule-nya belum dibuat akan dipesan saat generate
+		schedule, ok := existing[util.ScheduleKey(date, standingReservation.TimeID)]
+		if !ok {
+			continue
+		}
+
+		if schedule.Status != constants.Available && !util.IsHoldExpired(schedule, now) {
+			conflict.Reason = string(schedule.Status.GetStatusString())
+			conflicts = append(conflicts, conflict)
+			continue
+		}
+
+		available = append(available, schedule)
+	}
+
+	return available, conflicts, nil
+}
+
+func (s *StandingReservationService) Create(
+	ctx context.Context,
+	request *dto.StandingReservationRequest,
+) (*dto.StandingReservationResponse, error) {
+	standingReservation, dates, err := s.buildStandingReservation(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		response  dto.StandingReservationResponse
+		conflicts []dto.StandingReservationConflictResponse
+	)
+	err = s.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		// pembuatan standing reservation pada field yang sama diantrikan supaya cek overlap tidak balapan
+		err := s.repository.GetFieldSchedule().AdvisoryLock(
+			ctx,
+			tx,
+			constants.StandingReservationLockKey,
+			standingReservation.FieldID,
+		)
+		if err != nil {
+			return err
+		}
+
+		total, err := s.repository.GetStandingReservation().CountOverlap(ctx, tx, standingReservation)
+		if err != nil {
+			return err
+		}
+
+		if total > 0 {
+			return errStandingReservation.ErrStandingReservationOverlap
+		}
+
+		var available []models.FieldSchedule
+		available, conflicts, err = s.standingReservationConflicts(ctx, standingReservation, dates)
+		if err != nil {
+			return err
+		}
+
+		if len(conflicts) > 0 {
+			return errStandingReservation.ErrStandingReservationConflict
+		}
+
+		uuids := make([]string, 0, len(available))
+		for _, fieldSchedule := range available {
+			uuids = append(uuids, fieldSchedule.UUID.String())
+		}
+
+		standingReservationCreated, err := s.repository.GetStandingReservation().Create(ctx, tx, standingReservation)
+		if err != nil {
+			return err
+		}
+		standingReservationCreated.Field = standingReservation.Field
+		standingReservationCreated.Time = standingReservation.Time
+		response = s.toResponse(standingReservationCreated)
+
+		if len(uuids) == 0 {
+			return nil
+		}
+
+		// schedule yang sudah ada dikunci ulang karena statusnya bisa berubah sejak pengecekan konflik
+		fieldSchedules, lockConflicts, err := s.fieldSchedule.LockFieldSchedules(ctx, tx, uuids, constants.Available)
+		if err != nil {
+			return err
+		}
+
+		if len(lockConflicts) > 0 {
+			for _, conflict := range lockConflicts {
+				conflicts = append(conflicts, dto.StandingReservationConflictResponse{
+					Date:   conflict.Date,
+					Reason: string(conflict.Status),
+				})
+			}
+			return errStandingReservation.ErrStandingReservationConflict
+		}
+
+		_, linkedConflicts, err := s.fieldSchedule.BlockLinkedSchedules(ctx, tx, fieldSchedules)
+		if err != nil {
+			return err
+		}
+
+		if len(linkedConflicts) > 0 {
+			for _, conflict := range linkedConflicts {
+				conflicts = append(conflicts, dto.StandingReservationConflictResponse{
+					Date:   conflict.Date,
+					Reason: string(conflict.Status),
+				})
+			}
+			return errStandingReservation.ErrStandingReservationConflict
+		}
+
+		ids := util.FieldScheduleIDs(fieldSchedules)
+		err = s.repository.GetFieldSchedule().Book(ctx, tx, ids, &standingReservationCreated.OwnerID)
+		if err != nil {
+			return err
+		}
+
+		err = s.repository.GetFieldSchedule().UpdateStandingReservationID(ctx, tx, &standingReservationCreated.ID, ids)
+		if err != nil {
+			return err
+		}
+
+		err = s.fieldSchedule.CloseWaitlistOffer(ctx, tx, ids, nil)
+		if err != nil {
+			return err
+		}
+
+		response.Reserved = len(fieldSchedules)
+		return s.fieldSchedule.SnapshotPrice(ctx, tx, fieldSchedules)
+	})
+	if err != nil {
+		if len(conflicts) > 0 {
+			return &dto.StandingReservationResponse{Conflicts: conflicts}, err
+		}
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// Cancel membatalkan seluruh seri, schedule yang belum dimulai dibuka kembali.
+func (s *StandingReservationService) Cancel(ctx context.Context, uuid string) error {
+	standingReservation, user, err := s.findStandingReservation(ctx, uuid)
+	if err != nil {
+		return err
+	}
+
+	if standingReservation.CancelledAt != nil {
+		return errStandingReservation.ErrStandingReservationCancelled
+	}
+
+	now := time.Now()
+	return s.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		err := s.fieldSchedule.LockFieldGroups(ctx, tx, []uint{standingReservation.FieldID})
+		if err != nil {
+			return err
+		}
+
+		fieldSchedules, err := s.repository.GetFieldSchedule().FindAllByStandingReservationIDForUpdate(
+			ctx,
+			tx,
+			standingReservation.ID,
+			now.Format(time.DateOnly),
+		)
+		if err != nil {
+			return err
+		}
+
+		released := make([]models.FieldSchedule, 0, len(fieldSchedules))
+		histories := make([]models.FieldScheduleHistory, 0, len(fieldSchedules))
+		for _, fieldSchedule := range fieldSchedules {
+			startAt, err := util.ScheduleStartAt(&fieldSchedule)
+			if err != nil {
+				return err
+			}
+
+			if fieldSchedule.Status != constants.Booked || !startAt.After(now) {
+				continue
+			}
+
+			released = append(released, fieldSchedule)
+			histories = append(histories, models.FieldScheduleHistory{
+				FieldScheduleID: fieldSchedule.ID,
+				Status:          constants.Available,
+				Reason:          fmt.Sprintf("standing reservation %s cancelled", standingReservation.Name),
+				CreatedBy:       user.Username,
+			})
+		}
+
+		err = s.repository.GetStandingReservation().Cancel(ctx, tx, standingReservation.ID, now)
+		if err != nil {
+			return err
+		}
+
+		if len(released) == 0 {
+			return nil
+		}
+
+		ids := util.FieldScheduleIDs(released)
+		err = s.repository.GetFieldSchedule().UpdateStatus(ctx, tx, constants.Available, ids)
+		if err != nil {
+			return err
+		}
+
+		err = s.repository.GetFieldSchedule().UpdateStandingReservationID(ctx, tx, nil, ids)
+		if err != nil {
+			return err
+		}
+
+		err = s.repository.GetFieldScheduleHistory().Create(ctx, tx, histories)
+		if err != nil {
+			return err
+		}
+
+		opened, err := s.fieldSchedule.UnblockLinkedSchedules(ctx, tx, released)
+		if err != nil {
+			return err
+		}
+
+		return s.fieldSchedule.OfferToWaitlist(ctx, tx, append(released, opened...))
+	})
+}
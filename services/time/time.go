@@ -2,9 +2,15 @@ package services
 
 import (
 	"context"
+	"field-service/common/pricing"
+	"field-service/common/util"
+	"field-service/constants"
+	errTime "field-service/constants/error/time"
 	"field-service/domain/dto"
 	"field-service/domain/models"
 	"field-service/repositories"
+	"gorm.io/gorm"
+	timePkg "time"
 )
 
 type TimeService struct {
@@ -15,6 +21,9 @@ type ITimeService interface {
 	GetAll(context.Context) ([]dto.TimeResponse, error)
 	GetByUUID(context.Context, string) (*dto.TimeResponse, error)
 	Create(context.Context, *dto.TimeRequest) (*dto.TimeResponse, error)
+	Update(context.Context, string, *dto.UpdateTimeRequest) (*dto.TimeResponse, error)
+	Delete(context.Context, string) error
+	NormalizeStoredTimes(context.Context) (int64, error)
 }
 
 func NewTimeService(repository repositories.IRepositoryRegistry) ITimeService {
@@ -29,13 +38,7 @@ func (t *TimeService) GetAll(ctx context.Context) ([]dto.TimeResponse, error) {
 
 	timeResults := make([]dto.TimeResponse, 0, len(times))
 	for _, time := range times {
-		timeResults = append(timeResults, dto.TimeResponse{
-			UUID:      time.UUID,
-			StartTime: time.StartTime,
-			EndTime:   time.EndTime,
-			CreatedAt: time.CreatedAt,
-			UpdatedAt: time.UpdatedAt,
-		})
+		timeResults = append(timeResults, t.toResponse(&time))
 	}
 
 	return timeResults, nil
@@ -47,7 +50,12 @@ func (t *TimeService) GetByUUID(ctx context.Context, uuid string) (*dto.TimeResp
 		return nil, err
 	}
 
-	timeResult := dto.TimeResponse{
+	timeResult := t.toResponse(time)
+	return &timeResult, nil
+}
+
+func (t *TimeService) toResponse(time *models.Time) dto.TimeResponse {
+	response := dto.TimeResponse{
 		UUID:      time.UUID,
 		StartTime: time.StartTime,
 		EndTime:   time.EndTime,
@@ -55,25 +63,236 @@ func (t *TimeService) GetByUUID(ctx context.Context, uuid string) (*dto.TimeResp
 		UpdatedAt: time.UpdatedAt,
 	}
 
-	return &timeResult, nil
+	if time.TimeSet != nil {
+		response.TimeSetID = &time.TimeSet.UUID
+	}
+
+	return response
+}
+
+func (t *TimeService) validateTime(
+	ctx context.Context,
+	request *dto.TimeRequest,
+	timeSetID *uint,
+	excludeID uint,
+) (string, string, error) {
+	startTime, err := util.NormalizeTime(request.StartTime)
+	if err != nil {
+		return "", "", err
+	}
+
+	endTime, err := util.NormalizeTime(request.EndTime)
+	if err != nil {
+		return "", "", err
+	}
+
+	// format HH:MM:SS bisa dibandingkan langsung sebagai string, slot yang melewati tengah malam
+	// (misalnya 23:00 - 01:00) tidak didukung dan harus dipecah menjadi dua slot
+	if endTime <= startTime {
+		return "", "", errTime.ErrInvalidTimeRange
+	}
+
+	bufferMinute, err := t.repository.GetField().FindMaxBufferMinuteByTimeSetID(ctx, timeSetID)
+	if err != nil {
+		return "", "", err
+	}
+
+	err = util.CheckBuffer(startTime, endTime, bufferMinute)
+	if err != nil {
+		return "", "", err
+	}
+
+	overlap, err := t.repository.GetTime().FindOverlap(ctx, timeSetID, startTime, endTime, excludeID)
+	if err != nil {
+		return "", "", err
+	}
+
+	if overlap != nil {
+		return "", "", errTime.ErrTimeOverlap
+	}
+
+	return startTime, endTime, nil
 }
 
 func (t *TimeService) Create(ctx context.Context, request *dto.TimeRequest) (*dto.TimeResponse, error) {
+	// time set kosong berarti time masuk ke time set default
+	var timeSet *models.TimeSet
+	var timeSetID *uint
+	if request.TimeSetID != "" {
+		timeSetResult, err := t.repository.GetTimeSet().FindByUUID(ctx, request.TimeSetID)
+		if err != nil {
+			return nil, err
+		}
+		timeSet = timeSetResult
+		timeSetID = &timeSet.ID
+	}
+
+	startTime, endTime, err := t.validateTime(ctx, request, timeSetID, 0)
+	if err != nil {
+		return nil, err
+	}
+
 	timeCreated, err := t.repository.GetTime().Create(ctx, &models.Time{
+		TimeSetID: timeSetID,
+		StartTime: startTime,
+		EndTime:   endTime,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	timeCreated.TimeSet = timeSet
+	response := t.toResponse(timeCreated)
+	return &response, nil
+}
+
+// splitSchedules memisahkan schedule yang sudah lewat dengan schedule mendatang yang masih terpakai time ini.
+func (t *TimeService) splitSchedules(
+	ctx context.Context,
+	tx *gorm.DB,
+	timeID uint,
+) ([]uint, []models.FieldSchedule, error) {
+	fieldSchedules, err := t.repository.GetFieldSchedule().FindAllByTimeIDForUpdate(ctx, tx, timeID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := timePkg.Now()
+	pastIDs := make([]uint, 0)
+	upcoming := make([]models.FieldSchedule, 0)
+	for _, fieldSchedule := range fieldSchedules {
+		// schedule dianggap lewat setelah jam selesainya, bukan setelah tanggalnya
+		endAt, err := util.ScheduleEndAt(&fieldSchedule)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if !endAt.After(now) {
+			pastIDs = append(pastIDs, fieldSchedule.ID)
+			continue
+		}
+
+		isHoldActive := fieldSchedule.Status == constants.Held &&
+			fieldSchedule.HeldUntil != nil &&
+			fieldSchedule.HeldUntil.After(now)
+		if fieldSchedule.Status == constants.Booked || isHoldActive {
+			return nil, nil, errTime.ErrTimeHasBookedSchedule
+		}
+
+		upcoming = append(upcoming, fieldSchedule)
+	}
+
+	return pastIDs, upcoming, nil
+}
+
+// resnapshotPrices menghitung ulang harga snapshot schedule mendatang yang ikut pindah ke jam baru,
+// karena durasi dan pricing rule yang kena bisa berubah. Schedule booked sudah ditolak di splitSchedules.
+func (t *TimeService) resnapshotPrices(
+	ctx context.Context,
+	tx *gorm.DB,
+	fieldSchedules []models.FieldSchedule,
+	scheduleTime *models.Time,
+) error {
+	if len(fieldSchedules) == 0 {
+		return nil
+	}
+
+	pricingRules, err := t.repository.GetPricingRule().FindAllByFieldIDs(ctx, util.FieldIDs(fieldSchedules))
+	if err != nil {
+		return err
+	}
+
+	for i := range fieldSchedules {
+		err = pricing.Snapshot(&fieldSchedules[i], &fieldSchedules[i].Field, scheduleTime, pricingRules)
+		if err != nil {
+			return err
+		}
+	}
+
+	return t.repository.GetFieldSchedule().UpdatePrices(ctx, tx, fieldSchedules)
+}
+
+func (t *TimeService) Update(ctx context.Context, uuid string, request *dto.UpdateTimeRequest) (*dto.TimeResponse, error) {
+	time, err := t.repository.GetTime().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	startTime, endTime, err := t.validateTime(ctx, &dto.TimeRequest{
 		StartTime: request.StartTime,
 		EndTime:   request.EndTime,
-	})
+	}, time.TimeSetID, time.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	response := dto.TimeResponse{
-		UUID:      timeCreated.UUID,
-		StartTime: timeCreated.StartTime,
-		EndTime:   timeCreated.EndTime,
-		CreatedAt: timeCreated.CreatedAt,
-		UpdatedAt: timeCreated.UpdatedAt,
+	var timeUpdated *models.Time
+	err = t.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		pastIDs, upcoming, txErr := t.splitSchedules(ctx, tx, time.ID)
+		if txErr != nil {
+			return txErr
+		}
+
+		if len(upcoming) > 0 && !request.MoveSchedules {
+			return errTime.ErrTimeHasUpcomingSchedule
+		}
+
+		// schedule yang sudah lewat dipindah ke salinan time lama supaya riwayatnya tidak ikut berubah
+		if len(pastIDs) > 0 {
+			archive, txErr := t.repository.GetTime().CreateArchive(ctx, tx, time)
+			if txErr != nil {
+				return txErr
+			}
+
+			txErr = t.repository.GetFieldSchedule().UpdateTimeID(ctx, tx, archive.ID, pastIDs)
+			if txErr != nil {
+				return txErr
+			}
+		}
+
+		timeUpdated, txErr = t.repository.GetTime().Update(ctx, tx, uuid, &models.Time{
+			StartTime: startTime,
+			EndTime:   endTime,
+		})
+		if txErr != nil {
+			return txErr
+		}
+
+		return t.resnapshotPrices(ctx, tx, upcoming, timeUpdated)
+	})
+	if err != nil {
+		return nil, err
 	}
 
+	response := t.toResponse(timeUpdated)
 	return &response, nil
 }
+
+func (t *TimeService) Delete(ctx context.Context, uuid string) error {
+	time, err := t.repository.GetTime().FindByUUID(ctx, uuid)
+	if err != nil {
+		return err
+	}
+
+	return t.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		_, upcoming, txErr := t.splitSchedules(ctx, tx, time.ID)
+		if txErr != nil {
+			return txErr
+		}
+
+		// schedule yang sudah lewat tetap menunjuk ke time yang di-soft delete
+		if len(upcoming) > 0 {
+			txErr = t.repository.GetFieldSchedule().DeleteByIDs(ctx, tx, util.FieldScheduleIDs(upcoming))
+			if txErr != nil {
+				return txErr
+			}
+		}
+
+		return t.repository.GetTime().Delete(ctx, tx, uuid)
+	})
+}
+
+// NormalizeStoredTimes menyamakan time lama ke format HH:MM:SS, dijalankan sekali lewat command normalize-time.
+func (t *TimeService) NormalizeStoredTimes(ctx context.Context) (int64, error) {
+	return t.repository.GetTime().NormalizeAll(ctx)
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	errTimeSet "field-service/constants/error/timeSet"
+	"field-service/domain/dto"
+	"field-service/domain/models"
+	"field-service/repositories"
+)
+
+type TimeSetService struct {
+	repository repositories.IRepositoryRegistry
+}
+
+type ITimeSetService interface {
+	GetAll(context.Context) ([]dto.TimeSetResponse, error)
+	GetByUUID(context.Context, string) (*dto.TimeSetResponse, error)
+	Create(context.Context, *dto.TimeSetRequest) (*dto.TimeSetResponse, error)
+	Update(context.Context, string, *dto.TimeSetRequest) (*dto.TimeSetResponse, error)
+	Delete(context.Context, string) error
+}
+
+func NewTimeSetService(repository repositories.IRepositoryRegistry) ITimeSetService {
+	return &TimeSetService{repository: repository}
+}
+
+func (t *TimeSetService) toResponse(timeSet *models.TimeSet) dto.TimeSetResponse {
+	return dto.TimeSetResponse{
+		UUID:      timeSet.UUID,
+		Name:      timeSet.Name,
+		CreatedAt: timeSet.CreatedAt,
+		UpdatedAt: timeSet.UpdatedAt,
+	}
+}
+
+func (t *TimeSetService) GetAll(ctx context.Context) ([]dto.TimeSetResponse, error) {
+	timeSets, err := t.repository.GetTimeSet().FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	timeSetResults := make([]dto.TimeSetResponse, 0, len(timeSets))
+	for _, timeSet := range timeSets {
+		timeSetResults = append(timeSetResults, t.toResponse(&timeSet))
+	}
+
+	return timeSetResults, nil
+}
+
+func (t *TimeSetService) GetByUUID(ctx context.Context, uuid string) (*dto.TimeSetResponse, error) {
+	timeSet, err := t.repository.GetTimeSet().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	response := t.toResponse(timeSet)
+	return &response, nil
+}
+
+func (t *TimeSetService) Create(ctx context.Context, request *dto.TimeSetRequest) (*dto.TimeSetResponse, error) {
+	timeSet, err := t.repository.GetTimeSet().Create(ctx, &models.TimeSet{
+		Name: request.Name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := t.toResponse(timeSet)
+	return &response, nil
+}
+
+func (t *TimeSetService) Update(ctx context.Context, uuid string, request *dto.TimeSetRequest) (*dto.TimeSetResponse, error) {
+	timeSet, err := t.repository.GetTimeSet().Update(ctx, uuid, &models.TimeSet{
+		Name: request.Name,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := t.toResponse(timeSet)
+	return &response, nil
+}
+
+func (t *TimeSetService) Delete(ctx context.Context, uuid string) error {
+	timeSet, err := t.repository.GetTimeSet().FindByUUID(ctx, uuid)
+	if err != nil {
+		return err
+	}
+
+	// time set yang masih dipakai field atau time tidak boleh dihapus
+	isUsed, err := t.repository.GetTimeSet().IsUsed(ctx, timeSet.ID)
+	if err != nil {
+		return err
+	}
+
+	if isUsed {
+		return errTimeSet.ErrTimeSetInUse
+	}
+
+	return t.repository.GetTimeSet().Delete(ctx, uuid)
+}
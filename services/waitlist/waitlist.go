@@ -0,0 +1,192 @@
+package services
+
+import (
+	"context"
+	clientUser "field-service/clients/user"
+	"field-service/common/util"
+	"field-service/constants"
+	errConstant "field-service/constants/error"
+	errFieldSchedule "field-service/constants/error/fieldSchedule"
+	errWaitlist "field-service/constants/error/waitlist"
+	"field-service/domain/dto"
+	"field-service/domain/models"
+	"field-service/repositories"
+	fieldScheduleService "field-service/services/field_schedule"
+	"fmt"
+	"gorm.io/gorm"
+	"time"
+)
+
+type WaitlistService struct {
+	repository    repositories.IRepositoryRegistry
+	fieldSchedule fieldScheduleService.IFieldScheduleLocker
+}
+
+type IWaitlistService interface {
+	GetAll(context.Context) ([]dto.WaitlistResponse, error)
+	Join(context.Context, *dto.WaitlistRequest) (*dto.WaitlistResponse, error)
+	Cancel(context.Context, string) error
+	Expire(context.Context) (int64, error)
+}
+
+func NewWaitlistService(
+	repository repositories.IRepositoryRegistry,
+	fieldSchedule fieldScheduleService.IFieldScheduleLocker,
+) IWaitlistService {
+	return &WaitlistService{repository: repository, fieldSchedule: fieldSchedule}
+}
+
+func (w *WaitlistService) currentUser(ctx context.Context) (*clientUser.UserData, error) {
+	user, ok := ctx.Value(constants.User).(*clientUser.UserData)
+	if !ok {
+		return nil, errConstant.ErrUnauthorized
+	}
+
+	return user, nil
+}
+
+func (w *WaitlistService) toResponse(waitlist *models.Waitlist) dto.WaitlistResponse {
+	fieldSchedule := waitlist.FieldSchedule
+	return dto.WaitlistResponse{
+		UUID:            waitlist.UUID,
+		FieldScheduleID: fieldSchedule.UUID,
+		FieldName:       fieldSchedule.Field.Name,
+		Date:            fieldSchedule.Date.Format(time.DateOnly),
+		Time:            fmt.Sprintf("%s - %s", fieldSchedule.Time.StartTime, fieldSchedule.Time.EndTime),
+		Status:          waitlist.Status.GetStatusString(),
+		OfferedUntil:    waitlist.OfferedUntil,
+		CreatedAt:       waitlist.CreatedAt,
+		UpdatedAt:       waitlist.UpdatedAt,
+	}
+}
+
+func (w *WaitlistService) Join(ctx context.Context, request *dto.WaitlistRequest) (*dto.WaitlistResponse, error) {
+	user, err := w.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldSchedule, err := w.repository.GetFieldSchedule().FindByUUID(ctx, request.FieldScheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	startAt, err := util.ScheduleStartAt(fieldSchedule)
+	if err != nil {
+		return nil, err
+	}
+
+	if !startAt.After(now) {
+		return nil, errFieldSchedule.ErrFieldScheduleInPast
+	}
+
+	// antrian hanya untuk schedule yang sedang tidak bisa dipesan
+	isHoldActive := fieldSchedule.Status == constants.Held && !util.IsHoldExpired(*fieldSchedule, now)
+	if fieldSchedule.Status != constants.Booked && !isHoldActive {
+		return nil, errWaitlist.ErrWaitlistNotAllowed
+	}
+
+	total, err := w.repository.GetWaitlist().CountActiveByFieldScheduleIDAndUserID(ctx, fieldSchedule.ID, user.UUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if total > 0 {
+		return nil, errWaitlist.ErrWaitlistIsExist
+	}
+
+	waitlist, err := w.repository.GetWaitlist().Create(ctx, &models.Waitlist{
+		FieldScheduleID: fieldSchedule.ID,
+		UserID:          user.UUID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	waitlist.FieldSchedule = *fieldSchedule
+	response := w.toResponse(waitlist)
+	return &response, nil
+}
+
+func (w *WaitlistService) GetAll(ctx context.Context) ([]dto.WaitlistResponse, error) {
+	user, err := w.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	waitlists, err := w.repository.GetWaitlist().FindAllByUserID(ctx, user.UUID)
+	if err != nil {
+		return nil, err
+	}
+
+	waitlistResults := make([]dto.WaitlistResponse, 0, len(waitlists))
+	for _, waitlist := range waitlists {
+		waitlistResults = append(waitlistResults, w.toResponse(&waitlist))
+	}
+
+	return waitlistResults, nil
+}
+
+func (w *WaitlistService) Cancel(ctx context.Context, uuid string) error {
+	user, err := w.currentUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	waitlist, err := w.repository.GetWaitlist().FindByUUID(ctx, uuid)
+	if err != nil {
+		return err
+	}
+
+	if waitlist.UserID != user.UUID {
+		return errWaitlist.ErrWaitlistNotFound
+	}
+
+	if waitlist.Status != constants.WaitlistWaiting && waitlist.Status != constants.WaitlistOffered {
+		return errWaitlist.ErrWaitlistNotActive
+	}
+
+	return w.repository.GetTx().Transaction(func(tx *gorm.DB) error {
+		fieldSchedules, _, err := w.fieldSchedule.LockFieldSchedules(ctx, tx, []string{waitlist.FieldSchedule.UUID.String()})
+		if err != nil {
+			return err
+		}
+
+		err = w.repository.GetWaitlist().UpdateStatus(ctx, tx, constants.WaitlistCancelled, []uint{waitlist.ID})
+		if err != nil {
+			return err
+		}
+
+		// schedule yang sudah dihapus tidak punya hold yang perlu diteruskan
+		if len(fieldSchedules) == 0 {
+			return nil
+		}
+
+		// hold dari tawaran yang dibatalkan langsung diteruskan ke antrian berikutnya
+		fieldSchedule := fieldSchedules[0]
+		isOfferedHold := waitlist.Status == constants.WaitlistOffered &&
+			fieldSchedule.Status == constants.Held &&
+			fieldSchedule.HeldBy != nil &&
+			*fieldSchedule.HeldBy == user.UUID
+		if !isOfferedHold {
+			return nil
+		}
+
+		err = w.repository.GetFieldSchedule().UpdateStatus(ctx, tx, constants.Available, []uint{fieldSchedule.ID})
+		if err != nil {
+			return err
+		}
+
+		opened, err := w.fieldSchedule.UnblockLinkedSchedules(ctx, tx, fieldSchedules)
+		if err != nil {
+			return err
+		}
+
+		return w.fieldSchedule.OfferToWaitlist(ctx, tx, append(fieldSchedules, opened...))
+	})
+}
+
+func (w *WaitlistService) Expire(ctx context.Context) (int64, error) {
+	return w.repository.GetWaitlist().ExpireBeforeDate(ctx, time.Now().Format(time.DateOnly))
+}